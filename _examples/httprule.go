@@ -13,6 +13,8 @@ func (m *Messaging) GetMessage(ctx context.Context, req *GetMessageRequest) (*Ge
 		MessageId: req.MessageId,
 		Message:   req.Message,
 		Tags:      req.Tags,
+		Priority:  req.Priority,
+		Labels:    req.Labels,
 	}, nil
 }
 
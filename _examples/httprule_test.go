@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -11,8 +12,13 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/weblfe/protoc-gen-api/pkg/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -457,3 +463,293 @@ func TestMessaging_CreateMessage(t *testing.T) {
 		})
 	}
 }
+
+// textMarshaler encodes messages in the protobuf text format.
+type textMarshaler struct{}
+
+func (textMarshaler) ContentType() string {
+	return "text/plain"
+}
+
+func (textMarshaler) Marshal(m proto.Message) ([]byte, error) {
+	return prototext.Marshal(m)
+}
+
+func (textMarshaler) Unmarshal(b []byte, m proto.Message) error {
+	return prototext.Unmarshal(b, m)
+}
+
+func TestMessaging_WithMarshaler(t *testing.T) {
+	handler := NewMessagingHTTPConverter(&Messaging{}).
+		WithMarshaler("text/plain", textMarshaler{}).
+		WithMarshaler("application/json", &runtime.JSONMarshaler{
+			MarshalOptions: protojson.MarshalOptions{UseProtoNames: true},
+		})
+	_, _, h := handler.UpdateMessageHTTPRule(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`message: "hello"`))
+	req.Header.Set("Content-Type", "text/plain")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	resp := &UpdateMessageResponse{}
+	if err := prototext.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/plain" || resp.MessageId != "abc1234" || resp.Message != "hello" {
+		t.Errorf("text/plain: %d %q %v", rec.Code, rec.Header().Get("Content-Type"), resp)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`{"message":"hello"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if !bytes.Contains(rec.Body.Bytes(), []byte(`"message_id"`)) {
+		t.Errorf("application/json: %q does not use proto names", rec.Body.String())
+	}
+
+	// Other converters keep the default marshalers.
+	_, _, h = NewMessagingHTTPConverter(&Messaging{}).UpdateMessageHTTPRule(nil)
+	req = httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`message: "hello"`))
+	req.Header.Set("Content-Type", "text/plain")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnsupportedMediaType {
+		t.Errorf("text/plain without WithMarshaler: %d", rec.Code)
+	}
+}
+
+func TestMessaging_Options(t *testing.T) {
+	_, _, h := NewMessagingHTTPConverter(&Messaging{},
+		runtime.WithMarshalOptions(protojson.MarshalOptions{UseProtoNames: true}),
+		runtime.WithUnmarshalOptions(protojson.UnmarshalOptions{DiscardUnknown: true}),
+	).UpdateMessageHTTPRule(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`{"message":"hello","unknown":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || !bytes.Contains(rec.Body.Bytes(), []byte(`"message_id"`)) {
+		t.Errorf("with options: %d %q", rec.Code, rec.Body.String())
+	}
+
+	_, _, h = NewMessagingHTTPConverter(&Messaging{}).UpdateMessageHTTPRule(nil)
+	req = httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`{"message":"hello","unknown":1}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code == http.StatusOK {
+		t.Errorf("without options: an unknown field is accepted")
+	}
+}
+
+func TestMessaging_Accept(t *testing.T) {
+	invoked := false
+	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		invoked = true
+		return handler(ctx, req)
+	}
+	_, _, h := NewMessagingHTTPConverter(&Messaging{}).GetMessageHTTPRule(nil, interceptor)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/messages/abc1234", nil)
+	req.Header.Set("Accept", "text/html,application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("text/html,application/json: %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	invoked = false
+	req = httptest.NewRequest(http.MethodGet, "/v1/messages/abc1234", nil)
+	req.Header.Set("Accept", "text/html")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotAcceptable || invoked {
+		t.Errorf("text/html: %d, invoked %v", rec.Code, invoked)
+	}
+}
+
+func TestMessaging_Form(t *testing.T) {
+	_, _, h := NewMessagingHTTPConverter(&Messaging{}).UpdateMessageHTTPRule(nil)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString("message=hello"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	resp := &UpdateMessageResponse{}
+	if err := protojson.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" || resp.MessageId != "abc1234" || resp.Message != "hello" {
+		t.Errorf("application/x-www-form-urlencoded: %d %q %v", rec.Code, rec.Header().Get("Content-Type"), resp)
+	}
+}
+
+func TestMessaging_EnumQuery(t *testing.T) {
+	_, _, h := NewMessagingHTTPConverter(&Messaging{}).GetMessageHTTPRule(nil)
+
+	for _, spec := range []struct {
+		query string
+		code  int
+		want  Priority
+	}{
+		{query: "priority=PRIORITY_HIGH", code: http.StatusOK, want: Priority_PRIORITY_HIGH},
+		{query: "priority=1", code: http.StatusOK, want: Priority_PRIORITY_HIGH},
+		{query: "priority=PRIORITY_LOW", code: http.StatusBadRequest},
+		{query: "priority=999", code: http.StatusBadRequest},
+		{query: "priority=-5", code: http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/messages/abc1234?"+spec.query, nil))
+		if rec.Code != spec.code {
+			t.Errorf("%s: status = %d; want %d", spec.query, rec.Code, spec.code)
+			continue
+		}
+		if spec.code != http.StatusOK {
+			continue
+		}
+		resp := &GetMessageResponse{}
+		if err := protojson.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+			t.Fatal(err)
+		}
+		if resp.Priority != spec.want {
+			t.Errorf("%s: priority = %v; want %v", spec.query, resp.Priority, spec.want)
+		}
+	}
+}
+
+func TestMessaging_MapQuery(t *testing.T) {
+	_, _, h := NewMessagingHTTPConverter(&Messaging{}).GetMessageHTTPRule(nil)
+
+	for _, spec := range []struct {
+		query string
+		code  int
+		want  map[string]string
+	}{
+		{query: "labels[env]=prod&labels[team]=core", code: http.StatusOK, want: map[string]string{"env": "prod", "team": "core"}},
+		{query: "labels[env]=dev&labels[env]=prod", code: http.StatusOK, want: map[string]string{"env": "prod"}},
+		{query: "labels[]=x", code: http.StatusBadRequest},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/messages/abc1234?"+spec.query, nil))
+		if rec.Code != spec.code {
+			t.Errorf("%s: status = %d; want %d", spec.query, rec.Code, spec.code)
+			continue
+		}
+		if spec.code != http.StatusOK {
+			continue
+		}
+		resp := &GetMessageResponse{}
+		if err := protojson.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(spec.want, resp.Labels); diff != "" {
+			t.Errorf("%s: labels (-want +got):\n%s", spec.query, diff)
+		}
+	}
+}
+
+func TestMessaging_InvalidBody(t *testing.T) {
+	var cbErr error
+	cb := func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+		cbErr = err
+		runtime.DefaultCallback(ctx, w, r, arg, ret, err)
+	}
+	_, _, h := NewMessagingHTTPConverter(&Messaging{}).UpdateMessageHTTPRule(cb)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`{"message":`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var bindErr *runtime.BindingError
+	if !errors.As(cbErr, &bindErr) || bindErr.Source != runtime.SourceBody || bindErr.Field != "" {
+		t.Errorf("callback error = %v; want a BindingError of the whole body", cbErr)
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d; want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestMessaging_Committed(t *testing.T) {
+	var committed bool
+	cb := func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+		committed = runtime.Committed(w)
+		runtime.DefaultCallback(ctx, w, r, arg, ret, err)
+	}
+	_, _, h := NewMessagingHTTPConverter(&Messaging{}).UpdateMessageHTTPRule(cb)
+
+	req := httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`{"message":"hello"}`))
+	req.Header.Set("Content-Type", "application/json")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	if !committed {
+		t.Errorf("the response is not committed after a successful call")
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`{"message":`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if committed {
+		t.Errorf("the response is committed before the error is written")
+	}
+	if rec.Code != http.StatusBadRequest || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("error response: %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+}
+
+func TestMessaging_Metadata(t *testing.T) {
+	for _, spec := range []struct {
+		err  error
+		code int
+	}{
+		{code: http.StatusOK},
+		{err: status.Error(codes.Unavailable, "unavailable"), code: http.StatusServiceUnavailable},
+	} {
+		interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if err := grpc.SetHeader(ctx, metadata.Pairs("x-request-id", "42")); err != nil {
+				return nil, err
+			}
+			if err := grpc.SetTrailer(ctx, metadata.Pairs("x-elapsed", "1ms")); err != nil {
+				return nil, err
+			}
+			if spec.err != nil {
+				return nil, spec.err
+			}
+			return handler(ctx, req)
+		}
+		_, _, h := NewMessagingHTTPConverter(&Messaging{}).UpdateMessageHTTPRule(runtime.DefaultCallback, interceptor)
+
+		req := httptest.NewRequest(http.MethodPut, "/v1/messages/abc1234/sub", bytes.NewBufferString(`{"message":"hello"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		res := rec.Result()
+		if res.StatusCode != spec.code {
+			t.Errorf("%v: status = %d; want %d", spec.err, res.StatusCode, spec.code)
+		}
+		if got := res.Header.Get("Grpc-Metadata-X-Request-Id"); got != "42" {
+			t.Errorf("%v: Grpc-Metadata-X-Request-Id = %q; want %q", spec.err, got, "42")
+		}
+		if got := res.Trailer.Get("Grpc-Metadata-X-Elapsed"); got != "1ms" {
+			t.Errorf("%v: trailer Grpc-Metadata-X-Elapsed = %q; want %q", spec.err, got, "1ms")
+		}
+	}
+}
+
+func BenchmarkMessaging_CreateMessage(b *testing.B) {
+	buf, err := proto.Marshal(&CreateMessageRequest{Opt: "option"})
+	if err != nil {
+		b.Fatal(err)
+	}
+	_, _, h := NewMessagingHTTPConverter(&Messaging{}).CreateMessageHTTPRule(nil)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/v1/messages/abc1234/subsub/submsg", bytes.NewReader(buf))
+		req.Header.Set("Content-Type", "application/protobuf")
+		h(httptest.NewRecorder(), req)
+	}
+}
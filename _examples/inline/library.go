@@ -0,0 +1,26 @@
+package inline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ LibraryHTTPService = (*Library)(nil)
+
+type Library struct{}
+
+func (l *Library) GetBook(ctx context.Context, req *GetBookRequest) (*Book, error) {
+	switch req.Id {
+	case "missing":
+		return nil, status.Error(codes.NotFound, "book not found")
+	case "wrapped":
+		return nil, fmt.Errorf("get book %s: %w", req.Id, status.Error(codes.NotFound, "book not found"))
+	case "broken":
+		return nil, errors.New("broken")
+	}
+	return &Book{Id: req.Id, Title: "title"}, nil
+}
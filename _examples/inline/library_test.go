@@ -0,0 +1,40 @@
+package inline
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestLibrary_DefaultCallback(t *testing.T) {
+	_, _, h := NewLibraryHTTPConverter(&Library{}).GetBookHTTPRule(nil)
+
+	for _, spec := range []struct {
+		id   string
+		code int
+		want *spb.Status
+	}{
+		{id: "missing", code: http.StatusNotFound, want: &spb.Status{Code: int32(codes.NotFound), Message: "book not found"}},
+		{id: "wrapped", code: http.StatusNotFound, want: &spb.Status{Code: int32(codes.NotFound), Message: "book not found"}},
+		{id: "broken", code: http.StatusInternalServerError, want: &spb.Status{Code: int32(codes.Unknown), Message: "broken"}},
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/books/"+spec.id, nil))
+		if rec.Code != spec.code || rec.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("%s: %d %q; want %d %q", spec.id, rec.Code, rec.Header().Get("Content-Type"), spec.code, "application/json")
+		}
+		got := &spb.Status{}
+		if err := protojson.Unmarshal(rec.Body.Bytes(), got); err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(spec.want, got, protocmp.Transform()); diff != "" {
+			t.Errorf("%s: status (-want +got):\n%s", spec.id, diff)
+		}
+	}
+}
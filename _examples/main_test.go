@@ -15,6 +15,7 @@ import (
 	spb "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -25,6 +26,12 @@ func (s *ErrorService) SayHello(ctx context.Context, req *HelloRequest) (*HelloR
 	return nil, errors.New("ERROR")
 }
 
+type NotFoundService struct{}
+
+func (s *NotFoundService) SayHello(ctx context.Context, req *HelloRequest) (*HelloReply, error) {
+	return nil, status.Errorf(codes.NotFound, "%s is not found", req.Name)
+}
+
 func TestEchoGreeterServer_SayHello(t *testing.T) {
 	type want struct {
 		StatusCode  int
@@ -334,6 +341,81 @@ func TestEchoGreeterServer_SayHello(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Default callback error without Content-Type",
+			reqFunc: func() (*http.Request, error) {
+				return httptest.NewRequest(http.MethodGet, "/", nil), nil
+			},
+			service: &ErrorService{},
+			cb:      nil,
+			wantErr: true,
+			want: &want{
+				StatusCode:  500,
+				ContentType: "application/json",
+				Resp: &spb.Status{
+					Code:    int32(codes.Unknown),
+					Message: "ERROR",
+				},
+			},
+		},
+		{
+			name: "Default callback error with Accept Protobuf",
+			reqFunc: func() (*http.Request, error) {
+				p := &HelloRequest{
+					Name: "John",
+				}
+
+				body := &bytes.Buffer{}
+				if err := json.NewEncoder(body).Encode(p); err != nil {
+					return nil, err
+				}
+
+				req := httptest.NewRequest(http.MethodPost, "/", body)
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Accept", "application/protobuf")
+				return req, nil
+			},
+			service: &ErrorService{},
+			cb:      nil,
+			wantErr: true,
+			want: &want{
+				StatusCode:  500,
+				ContentType: "application/protobuf",
+				Resp: &spb.Status{
+					Code:    int32(codes.Unknown),
+					Message: "ERROR",
+				},
+			},
+		},
+		{
+			name: "Default callback gRPC status error",
+			reqFunc: func() (*http.Request, error) {
+				p := &HelloRequest{
+					Name: "John",
+				}
+
+				body := &bytes.Buffer{}
+				if err := json.NewEncoder(body).Encode(p); err != nil {
+					return nil, err
+				}
+
+				req := httptest.NewRequest(http.MethodPost, "/", body)
+				req.Header.Set("Content-Type", "application/json")
+				req.Header.Set("Accept", "*/*")
+				return req, nil
+			},
+			service: &NotFoundService{},
+			cb:      nil,
+			wantErr: true,
+			want: &want{
+				StatusCode:  404,
+				ContentType: "application/json",
+				Resp: &spb.Status{
+					Code:    int32(codes.NotFound),
+					Message: "John is not found",
+				},
+			},
+		},
 	}
 
 	opts := cmpopts.IgnoreUnexported(
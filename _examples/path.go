@@ -0,0 +1,33 @@
+package main
+
+import (
+	"context"
+)
+
+var _ ResourcesHTTPService = (*Resources)(nil)
+
+type Resources struct{}
+
+func (r *Resources) GetTopic(ctx context.Context, req *ResourceMessage) (*ResourceMessage, error) {
+	return req, nil
+}
+
+func (r *Resources) ListSubscriptions(ctx context.Context, req *ResourceMessage) (*ResourceMessage, error) {
+	return req, nil
+}
+
+func (r *Resources) GetObject(ctx context.Context, req *ResourceMessage) (*ResourceMessage, error) {
+	return req, nil
+}
+
+func (r *Resources) CancelOperation(ctx context.Context, req *ResourceMessage) (*ResourceMessage, error) {
+	return req, nil
+}
+
+func (r *Resources) UpdateTopic(ctx context.Context, req *ResourceMessage) (*ResourceMessage, error) {
+	return req, nil
+}
+
+func (r *Resources) SearchObjects(ctx context.Context, req *ResourceMessage) (*ResourceMessage, error) {
+	return req, nil
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+func TestResources_Path(t *testing.T) {
+	conv := NewResourcesHTTPConverter(&Resources{})
+	_, _, getTopic := conv.GetTopicHTTPRule(nil)
+	_, _, listSubscriptions := conv.ListSubscriptionsHTTPRule(nil)
+	_, _, getObject := conv.GetObjectHTTPRule(nil)
+	_, _, cancelOperation := conv.CancelOperationHTTPRule(nil)
+
+	for _, spec := range []struct {
+		name    string
+		handler http.HandlerFunc
+		method  string
+		path    string
+		code    int
+		want    *ResourceMessage
+	}{
+		{
+			name:    "Multi-segment variable",
+			handler: getTopic,
+			path:    "/v1/projects/p1/topics/t1",
+			code:    http.StatusOK,
+			want:    &ResourceMessage{Name: "projects/p1/topics/t1"},
+		},
+		{
+			name:    "Multi-segment variable missing a segment",
+			handler: getTopic,
+			path:    "/v1/projects/p1/topics",
+			code:    http.StatusNotFound,
+		},
+		{
+			name:    "Multi-segment variable with a wrong literal",
+			handler: getTopic,
+			path:    "/v1/projects/p1/queues/t1",
+			code:    http.StatusNotFound,
+		},
+		{
+			name:    "Literal after a multi-segment variable",
+			handler: listSubscriptions,
+			path:    "/v1/projects/p1/topics/t1/subscriptions",
+			code:    http.StatusOK,
+			want:    &ResourceMessage{Name: "projects/p1/topics/t1"},
+		},
+		{
+			name:    "Wrong literal after a multi-segment variable",
+			handler: listSubscriptions,
+			path:    "/v1/projects/p1/topics/t1/snapshots",
+			code:    http.StatusNotFound,
+		},
+		{
+			name:    "Deep wildcard",
+			handler: getObject,
+			path:    "/v1/buckets/b1/objects/a/b/c.txt",
+			code:    http.StatusOK,
+			want:    &ResourceMessage{Bucket: "b1", Name: "a/b/c.txt"},
+		},
+		{
+			name:    "Deep wildcard of a single segment",
+			handler: getObject,
+			path:    "/v1/buckets/b1/objects/c.txt",
+			code:    http.StatusOK,
+			want:    &ResourceMessage{Bucket: "b1", Name: "c.txt"},
+		},
+		{
+			name:    "Escaped slash in a single-segment variable and in a deep wildcard",
+			handler: getObject,
+			path:    "/v1/buckets/b%2F1/objects/a%2Fb/c%20d.txt",
+			code:    http.StatusOK,
+			want:    &ResourceMessage{Bucket: "b/1", Name: "a%2Fb/c d.txt"},
+		},
+		{
+			name:    "Verb",
+			handler: cancelOperation,
+			method:  http.MethodPost,
+			path:    "/v1/operations/123:cancel",
+			code:    http.StatusOK,
+			want:    &ResourceMessage{Id: 123},
+		},
+		{
+			name:    "Wrong verb",
+			handler: cancelOperation,
+			method:  http.MethodPost,
+			path:    "/v1/operations/123:abort",
+			code:    http.StatusNotFound,
+		},
+		{
+			name:    "Missing verb",
+			handler: cancelOperation,
+			method:  http.MethodPost,
+			path:    "/v1/operations/123",
+			code:    http.StatusNotFound,
+		},
+		{
+			name:    "Typed variable that cannot be converted",
+			handler: cancelOperation,
+			method:  http.MethodPost,
+			path:    "/v1/operations/abc:cancel",
+			code:    http.StatusBadRequest,
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			method := spec.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			rec := httptest.NewRecorder()
+			spec.handler.ServeHTTP(rec, httptest.NewRequest(method, spec.path, nil))
+			if rec.Code != spec.code {
+				t.Fatalf("%s %s: status = %d; want %d: %s", method, spec.path, rec.Code, spec.code, rec.Body)
+			}
+			if spec.want == nil {
+				return
+			}
+			got := &ResourceMessage{}
+			if err := protojson.Unmarshal(rec.Body.Bytes(), got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(spec.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("%s %s: request (-want +got):\n%s", method, spec.path, diff)
+			}
+		})
+	}
+}
+
+func TestResources_Bindings(t *testing.T) {
+	conv := NewResourcesHTTPConverter(&Resources{})
+	type binding func(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc)
+
+	for _, spec := range []struct {
+		name        string
+		binding     binding
+		wantMethod  string
+		wantPattern string
+		path        string
+		body        string
+		want        *ResourceMessage
+	}{
+		{
+			name:        "Main binding",
+			binding:     conv.UpdateTopicHTTPRule,
+			wantMethod:  http.MethodPatch,
+			wantPattern: "/v1/{name=projects/*/topics/*}",
+			path:        "/v1/projects/p1/topics/t1?bucket=ignored",
+			body:        `{"bucket":"b1","id":"7"}`,
+			want:        &ResourceMessage{Name: "projects/p1/topics/t1", Bucket: "b1", Id: 7},
+		},
+		{
+			name:        "First additional binding",
+			binding:     conv.UpdateTopicHTTPRule1,
+			wantMethod:  http.MethodPut,
+			wantPattern: "/v1/buckets/{bucket}/topic",
+			path:        "/v1/buckets/b1/topic?id=7",
+			body:        `"projects/p1/topics/t1"`,
+			want:        &ResourceMessage{Name: "projects/p1/topics/t1", Bucket: "b1", Id: 7},
+		},
+		{
+			name:        "Second additional binding",
+			binding:     conv.UpdateTopicHTTPRule2,
+			wantMethod:  http.MethodPost,
+			wantPattern: "/v2/topics/{id}",
+			path:        "/v2/topics/7?name=projects/p1/topics/t1&bucket=b1",
+			want:        &ResourceMessage{Name: "projects/p1/topics/t1", Bucket: "b1", Id: 7},
+		},
+		{
+			name:        "Custom pattern",
+			binding:     conv.SearchObjectsHTTPRule,
+			wantMethod:  "SEARCH",
+			wantPattern: "/v1/buckets/{bucket}/objects",
+			path:        "/v1/buckets/b1/objects?name=a/",
+			want:        &ResourceMessage{Bucket: "b1", Name: "a/"},
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			method, pattern, h := spec.binding(nil)
+			if method != spec.wantMethod || pattern != spec.wantPattern {
+				t.Errorf("binding = %s %s; want %s %s", method, pattern, spec.wantMethod, spec.wantPattern)
+			}
+			req := httptest.NewRequest(method, spec.path, bytes.NewBufferString(spec.body))
+			if spec.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("%s %s: status = %d; want %d: %s", method, spec.path, rec.Code, http.StatusOK, rec.Body)
+			}
+			got := &ResourceMessage{}
+			if err := protojson.Unmarshal(rec.Body.Bytes(), got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(spec.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("%s %s: request (-want +got):\n%s", method, spec.path, diff)
+			}
+		})
+	}
+}
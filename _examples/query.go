@@ -0,0 +1,13 @@
+package main
+
+import (
+	"context"
+)
+
+var _ SearchHTTPService = (*Search)(nil)
+
+type Search struct{}
+
+func (s *Search) SearchItems(ctx context.Context, req *SearchRequest) (*SearchRequest, error) {
+	return req, nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSearch_Query(t *testing.T) {
+	conv := NewSearchHTTPConverter(&Search{})
+	_, _, search := conv.SearchItemsHTTPRule(nil)
+	_, _, remove := conv.SearchItemsHTTPRule1(nil)
+	_, _, searchBody := conv.SearchItemsHTTPRule2(nil)
+	_, _, cancelOperation := NewResourcesHTTPConverter(&Resources{}).CancelOperationHTTPRule(nil)
+
+	for _, spec := range []struct {
+		name      string
+		handler   http.HandlerFunc
+		method    string
+		target    string
+		body      string
+		want      *SearchRequest
+		violation string
+	}{
+		{
+			name:    "Well-known types",
+			handler: search,
+			target:  "/v1/items?created_after=2021-02-03T04:05:06Z&max_idle=1.5s&read_mask=itemId,filter.text&min_count=3",
+			want: &SearchRequest{
+				CreatedAfter: timestamppb.New(time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)),
+				MaxIdle:      durationpb.New(1500 * time.Millisecond),
+				ReadMask:     &fieldmaskpb.FieldMask{Paths: []string{"item_id", "filter.text"}},
+				MinCount:     wrapperspb.Int32(3),
+			},
+		},
+		{
+			name:      "Invalid Timestamp",
+			handler:   search,
+			target:    "/v1/items?created_after=yesterday",
+			violation: "created_after",
+		},
+		{
+			name:      "Invalid Duration",
+			handler:   search,
+			target:    "/v1/items?max_idle=10",
+			violation: "max_idle",
+		},
+		{
+			name:      "Invalid wrapper",
+			handler:   search,
+			target:    "/v1/items?min_count=many",
+			violation: "min_count",
+		},
+		{
+			name:    "Unset optional",
+			handler: search,
+			target:  "/v1/items",
+			want:    &SearchRequest{},
+		},
+		{
+			name:    "Optional set to zero",
+			handler: search,
+			target:  "/v1/items?page_size=0",
+			want:    &SearchRequest{PageSize: proto.Int32(0)},
+		},
+		{
+			name:    "Oneof",
+			handler: search,
+			target:  "/v1/items?group_id=7",
+			want:    &SearchRequest{Owner: &SearchRequest_GroupId{GroupId: 7}},
+		},
+		{
+			name:      "Oneof set twice",
+			handler:   search,
+			target:    "/v1/items?user=alice&group_id=7",
+			violation: "group_id",
+		},
+		{
+			name:    "Maps",
+			handler: search,
+			target:  "/v1/items?labels[env]=prod&states[1]=ITEM_STATE_ACTIVE&states[2]=0",
+			want: &SearchRequest{
+				Labels: map[string]string{"env": "prod"},
+				States: map[int64]ItemState{1: ItemState_ITEM_STATE_ACTIVE, 2: ItemState_ITEM_STATE_UNSPECIFIED},
+			},
+		},
+		{
+			name:      "Map key that cannot be converted",
+			handler:   search,
+			target:    "/v1/items?states[one]=ITEM_STATE_ACTIVE",
+			violation: "states[one]",
+		},
+		{
+			name:      "Unknown enum value of map",
+			handler:   search,
+			target:    "/v1/items?states[1]=ITEM_STATE_DELETED",
+			violation: "states[1]",
+		},
+		{
+			name:      "Empty map key",
+			handler:   search,
+			target:    "/v1/items?states[]=0",
+			violation: "states[]",
+		},
+		{
+			name:    "Repeated enum",
+			handler: search,
+			target:  "/v1/items?include=ITEM_STATE_ACTIVE&include=0",
+			want:    &SearchRequest{Include: []ItemState{ItemState_ITEM_STATE_ACTIVE, ItemState_ITEM_STATE_UNSPECIFIED}},
+		},
+		{
+			name:      "Unknown enum value of repeated field",
+			handler:   search,
+			target:    "/v1/items?include=9",
+			violation: "include",
+		},
+		{
+			name:    "Nested messages",
+			handler: search,
+			target:  "/v1/items?filter.parent.parent.text=b",
+			want:    &SearchRequest{Filter: &Filter{Parent: &Filter{Parent: &Filter{Text: "b"}}}},
+		},
+		{
+			name:    "Nested messages deeper than the query_max_depth",
+			handler: search,
+			target:  "/v1/items?filter.text=a&filter.parent.parent.parent.parent.parent.text=b",
+			want:    &SearchRequest{Filter: &Filter{Text: "a"}},
+		},
+		{
+			name:    "DELETE binding",
+			handler: remove,
+			method:  http.MethodDelete,
+			target:  "/v1/items/abc?page_size=10&labels[env]=prod",
+			want: &SearchRequest{
+				ItemId:   "abc",
+				PageSize: proto.Int32(10),
+				Labels:   map[string]string{"env": "prod"},
+			},
+		},
+		{
+			name:    "POST binding of a body field",
+			handler: searchBody,
+			method:  http.MethodPost,
+			target:  "/v1/items:search?user=alice&filter.text=ignored",
+			body:    `{"text":"a","children":[{"text":"b"}]}`,
+			want: &SearchRequest{
+				Owner:  &SearchRequest_User{User: "alice"},
+				Filter: &Filter{Text: "a", Children: []*Filter{{Text: "b"}}},
+			},
+		},
+		{
+			name:      "Typed path variable that cannot be converted",
+			handler:   cancelOperation,
+			method:    http.MethodPost,
+			target:    "/v1/operations/abc:cancel?name=ignored",
+			violation: "id",
+		},
+		{
+			name:      "POST binding with an invalid query",
+			handler:   searchBody,
+			method:    http.MethodPost,
+			target:    "/v1/items:search?max_idle=forever",
+			body:      `{}`,
+			violation: "max_idle",
+		},
+	} {
+		t.Run(spec.name, func(t *testing.T) {
+			method := spec.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, spec.target, strings.NewReader(spec.body))
+			if spec.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			rec := httptest.NewRecorder()
+			spec.handler.ServeHTTP(rec, req)
+
+			if spec.violation != "" {
+				if rec.Code != http.StatusBadRequest {
+					t.Fatalf("%s %s: status = %d; want %d: %s", method, spec.target, rec.Code, http.StatusBadRequest, rec.Body)
+				}
+				sp := &spb.Status{}
+				if err := protojson.Unmarshal(rec.Body.Bytes(), sp); err != nil {
+					t.Fatal(err)
+				}
+				s := status.FromProto(sp)
+				if s.Code() != codes.InvalidArgument {
+					t.Errorf("code = %v; want %v", s.Code(), codes.InvalidArgument)
+				}
+				details := s.Details()
+				if len(details) != 1 {
+					t.Fatalf("details = %v; want a BadRequest", details)
+				}
+				br, ok := details[0].(*errdetails.BadRequest)
+				if !ok || len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != spec.violation {
+					t.Errorf("details = %v; want a violation of %q", details, spec.violation)
+				}
+				return
+			}
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("%s %s: status = %d; want %d: %s", method, spec.target, rec.Code, http.StatusOK, rec.Body)
+			}
+			got := &SearchRequest{}
+			if err := protojson.Unmarshal(rec.Body.Bytes(), got); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(spec.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("%s %s: request (-want +got):\n%s", method, spec.target, diff)
+			}
+		})
+	}
+}
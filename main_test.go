@@ -23,10 +23,9 @@ func init() {
 }
 
 func TestGolden(t *testing.T) {
-	str, _ := os.Getwd()
-	workdir := filepath.Join(str, "testdata")
+	// The files are generated out of testdata, to be compared with the golden ones.
+	workdir := t.TempDir()
 	flagOut:=`api_out`
-	// defer os.RemoveAll(workdir)
 
 	// Find all the proto files in testdata.
 	packages := map[string][]string{}
@@ -43,14 +42,24 @@ func TestGolden(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// Plugin parameters of the packages not generated with the defaults.
+	params := map[string]string{
+		filepath.Join("testdata", "inline"):      "inline=true",
+		filepath.Join("testdata", "jsonoptions"): "json_use_proto_names=true,json_emit_unpopulated=true,json_discard_unknown=true",
+	}
+
 	// Compile each package, using this binary as protoc-gen-api.
-	for _, sources := range packages {
+	for dir, sources := range packages {
 		args := []string{"-Itestdata", fmt.Sprintf("--%s=%s" ,flagOut, workdir)}
+		if param, ok := params[dir]; ok {
+			args = append(args, fmt.Sprintf("--api_opt=%s", param))
+		}
 		args = append(args, sources...)
 		protoc(t, args)
 	}
 
 	// Compare each generated file to the golden version.
+	generated := map[string]bool{}
 	if err := filepath.Walk(workdir, func(genPath string, info os.FileInfo, _ error) error {
 		if info.IsDir() {
 			return nil
@@ -72,6 +81,7 @@ func TestGolden(t *testing.T) {
 		}
 
 		goldenPath := filepath.Join("testdata", relPath)
+		generated[goldenPath] = true
 		want, err := ioutil.ReadFile(goldenPath)
 		if err != nil {
 			t.Error(err)
@@ -89,19 +99,116 @@ func TestGolden(t *testing.T) {
 	}); err != nil {
 		t.Fatal(err)
 	}
+
+	// Every golden file must still be generated.
+	if err := filepath.Walk("testdata", func(path string, info os.FileInfo, _ error) error {
+		if strings.HasSuffix(path, ".http.go") && !generated[path] {
+			t.Errorf("golden file %q is not generated", path)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	const source = `syntax = "proto3";
+package invalid;
+option go_package = "./invalid;invalid";
+import "google/api/annotations.proto";
+import "google/protobuf/wrappers.proto";
+service Service {
+  rpc Method(Request) returns (Request) {
+    option (google.api.http) = { %s };
+  }
+}
+message Sub { string name = 1; }
+message Request {
+  string id = 1;
+  repeated string tags = 2;
+  map<string, string> labels = 3;
+  int64 count = 4;
+  Sub sub = 5;
+  repeated Sub subs = 6;
+}
+`
+	for _, spec := range []struct {
+		rule string
+		want string
+	}{
+		{
+			rule: `post: "/v1/{id}" body: "unknown"`,
+			want: `invalid.Service.Method: body field "unknown" not found in invalid.Request`,
+		},
+		{
+			rule: `post: "/v1/{id}" body: "tags"`,
+			want: `invalid.Service.Method: body field "tags" must not be a repeated scalar`,
+		},
+		{
+			rule: `get: "/v1/{id}" response_body: "unknown"`,
+			want: `invalid.Service.Method: response_body field "unknown" not found in invalid.Request`,
+		},
+		{
+			rule: `get: "/v1/{unknown}"`,
+			want: `invalid.Service.Method: path parameter "unknown" not found in invalid.Request`,
+		},
+		{
+			rule: `get: "/v1/{tags}"`,
+			want: `invalid.Service.Method: path parameter "tags" must be a scalar, enum or wrapper field`,
+		},
+		{
+			rule: `get: "/v1/{labels}"`,
+			want: `invalid.Service.Method: path parameter "labels" must be a scalar, enum or wrapper field`,
+		},
+		{
+			rule: `get: "/v1/{subs.name}"`,
+			want: `invalid.Service.Method: path parameter "subs.name" must only traverse singular message fields`,
+		},
+		{
+			rule: `get: "/v1/{count=counts/*}"`,
+			want: `invalid.Service.Method: path parameter "count" spanning several segments must be a string field`,
+		},
+		{
+			rule: `get: "v1/{id}"`,
+			want: `no leading /`,
+		},
+		{
+			rule: `get: "/v1/{id"`,
+			want: `unterminated Variable Segment: id`,
+		},
+		{
+			rule: `get: "/v1/{id=**}/name"`,
+			want: `/v1/{id=**}/name: ** must be the last segment`,
+		},
+		{
+			rule: `get: "/v1/{id}:cancel:now"`,
+			want: `unexpected token ":cancel" after Segments "v1/{id}"`,
+		},
+		{
+			rule: `custom: {kind: "" path: "/v1/{id}"}`,
+			want: `invalid.Service.Method: custom pattern has no kind`,
+		},
+		{
+			rule: `get: "/v1/{id}" additional_bindings { get: "/v2/{id}" additional_bindings { get: "/v3/{id}" } }`,
+			want: `invalid.Service.Method: additional_bindings must not be nested`,
+		},
+	} {
+		dir := t.TempDir()
+		if err := ioutil.WriteFile(filepath.Join(dir, "invalid.proto"), []byte(fmt.Sprintf(source, spec.rule)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		out, err := runProtoc("-Itestdata", "-I"+dir, "--api_out="+dir, filepath.Join(dir, "invalid.proto"))
+		if err == nil || !strings.Contains(string(out), spec.want) {
+			t.Errorf("generating %s = %v, %q; want an error containing %q", spec.rule, err, out, spec.want)
+		}
+	}
 }
 
 func protoc(t *testing.T, args []string) {
-	cmd := exec.Command("protoc", fmt.Sprintf("--plugin=%s=%s",core.GetName(),os.Args[0]))
-	cmd.Args = append(cmd.Args, args...)
-	// fmt.Println(cmd.String())
-	// We set the RUN_AS_PROTOC_GEN_GO environment variable to indicate that
-	// the subprocess should act as a proto compiler rather than a test.
-	cmd.Env = append(os.Environ(), "RUN_AS_PROTOC_GEN_GO=1")
-	out, err := cmd.CombinedOutput()
+	out, err := runProtoc(args...)
 	if len(out) > 0 || err != nil {
 		t.Log("Error:", err)
-		t.Log("RUNNING: ", strings.Join(cmd.Args, " "))
+		t.Log("RUNNING: protoc ", strings.Join(args, " "))
 	}
 	if len(out) > 0 {
 		t.Log(string(out))
@@ -110,3 +217,14 @@ func protoc(t *testing.T, args []string) {
 		t.Fatalf("protoc: %v", err)
 	}
 }
+
+// runProtoc runs protoc with args, using this binary as protoc-gen-api, and returns
+// its combined output.
+func runProtoc(args ...string) ([]byte, error) {
+	cmd := exec.Command("protoc", fmt.Sprintf("--plugin=%s=%s",core.GetName(),os.Args[0]))
+	cmd.Args = append(cmd.Args, args...)
+	// We set the RUN_AS_PROTOC_GEN_GO environment variable to indicate that
+	// the subprocess should act as a proto compiler rather than a test.
+	cmd.Env = append(os.Environ(), "RUN_AS_PROTOC_GEN_GO=1")
+	return cmd.CombinedOutput()
+}
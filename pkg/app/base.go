@@ -1,6 +1,7 @@
 package app
 
 import (
+		"flag"
 		"google.golang.org/protobuf/compiler/protogen"
 		"google.golang.org/protobuf/types/pluginpb"
 		"io"
@@ -15,6 +16,7 @@ type ProtocPlugin struct {
 	locker     sync.Locker
 	request    *pluginpb.CodeGeneratorRequest
 	generators map[string]Generator
+	flags      *flag.FlagSet
 }
 
 var (
@@ -29,6 +31,12 @@ type Generator interface {
 	Generate(*protogen.Plugin, *protogen.File) (*protogen.GeneratedFile, error)
 }
 
+// FlagRegister is implemented by a Generator reading plugin parameters,
+// given like `--api_opt=name=value`.
+type FlagRegister interface {
+	RegisterFlags(*flag.FlagSet)
+}
+
 type Option func(*ProtocPlugin)
 
 func SetVersion(v string)  {
@@ -91,6 +99,12 @@ func (p *ProtocPlugin) Apply(options ...Option) {
 func (p *ProtocPlugin) init() {
 	p.locker = &sync.RWMutex{}
 	p.generators = make(map[string]Generator, 0)
+	p.flags = flag.NewFlagSet("protoc-gen-api", flag.ContinueOnError)
+}
+
+// Flags returns the flag set the plugin parameters are parsed into.
+func (p *ProtocPlugin) Flags() *flag.FlagSet {
+	return p.flags
 }
 
 func (p *ProtocPlugin) Register(generators ...Generator) {
@@ -101,6 +115,9 @@ func (p *ProtocPlugin) Register(generators ...Generator) {
 			continue
 		}
 		p.generators[g.Name()] = g
+		if r, ok := g.(FlagRegister); ok {
+			r.RegisterFlags(p.flags)
+		}
 	}
 }
 
@@ -114,7 +131,8 @@ func (p *ProtocPlugin) Run() error {
 					}
 			}
 	}()
-	(protogen.Options{}).Run(func(plugin *protogen.Plugin) error {
+	(protogen.Options{ParamFunc: p.flags.Set}).Run(func(plugin *protogen.Plugin) error {
+			plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
 			return  p.MakeFiles(plugin)
 	})
 	return err
@@ -151,6 +169,6 @@ func (p *ProtocPlugin) generate(plugin *protogen.Plugin, fs *protogen.File) erro
 }
 
 func (p *ProtocPlugin) GetProtoGenPlugin(req *pluginpb.CodeGeneratorRequest) (*protogen.Plugin, error) {
-	var opts = new(protogen.Options)
+	var opts = &protogen.Options{ParamFunc: p.flags.Set}
 	return opts.New(req)
 }
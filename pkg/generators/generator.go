@@ -19,10 +19,16 @@ func toCamelCase(str string) string {
 	})
 }
 
+// PathParam is a variable of a path template bound to the segments p[Start:End].
+// End is -1 when the variable extends to the last segment.
 type PathParam struct {
-	Index  int
+	Start  int
+	End    int
 	Name   string
 	GoName string
+	Field  *protogen.Field
+	// Parents are the message fields walked into to reach Field.
+	Parents []*protogen.Field
 }
 
 type PathParamArr []*PathParam
@@ -43,52 +49,186 @@ func (p PathParamArr) Swap(i, j int) {
 	p[i], p[j] = p[j], p[i]
 }
 
-func (t *PathParam) GetGoNamesWithSplit() []string {
-	names := make([]string, 0)
-	ps := strings.Split(t.GoName, ".")
-	for i := range ps {
-		if i == 0 {
-			continue
-		}
-		names = append(names, strings.Join(ps[0:i], "."))
+// PathTemplate is a HttpRule path template flattened into single segments.
+type PathTemplate struct {
+	Segments []grammar.Segment
+	Params   PathParamArr
+	Verb     string
+}
+
+// MinLen returns the least number of segments of a path matching the template.
+func (t *PathTemplate) MinLen() int {
+	if t.HasDeepWildcard() {
+		return len(t.Segments) - 1
 	}
-	return names
+	return len(t.Segments)
 }
 
-func parsePathParam(pattern string) ([]*PathParam, error) {
+// HasDeepWildcard reports whether the template ends with "**".
+func (t *PathTemplate) HasDeepWildcard() bool {
+	if len(t.Segments) == 0 {
+		return false
+	}
+	_, ok := t.Segments[len(t.Segments)-1].(grammar.DeepWildcard)
+	return ok
+}
+
+func parsePathTemplate(pattern string) (*PathTemplate, error) {
 	if !strings.HasPrefix(pattern, "/") {
 		return nil, fmt.Errorf("no leading /")
 	}
 	var (
-		err       error
-		segments  []grammar.Segment
-		tokens, _ = grammar.Tokenize(pattern[1:])
+		err          error
+		segments     []grammar.Segment
+		tokens, verb = grammar.Tokenize(pattern[1:])
 	)
 	p := grammar.NewParser(grammar.ApplyTokens(tokens...))
 	if segments, err = p.TopLevelSegments(); err != nil {
 		return nil, err
 	}
-	var params PathParamArr
-	for i, seg := range segments {
-		switch seg.(type) {
-		case grammar.Variable:
-			var v = seg.(grammar.Variable)
-			params = append(params, &PathParam{
-				Index:  i + 1,
-				Name:   v.GetPath(),
-				GoName: toCamelCase(v.GetPath()),
-			})
-		case *grammar.Variable:
-			var v = seg.(*grammar.Variable)
-			params = append(params, &PathParam{
-				Index:  i + 1,
-				Name:   v.GetPath(),
-				GoName: toCamelCase(v.GetPath()),
-			})
+	var tmpl = &PathTemplate{Verb: verb}
+	for _, seg := range segments {
+		v, ok := seg.(grammar.Variable)
+		if !ok {
+			tmpl.Segments = append(tmpl.Segments, seg)
+			continue
+		}
+		param := &PathParam{
+			Start: len(tmpl.Segments),
+			Name:  v.GetPath(),
+		}
+		tmpl.Segments = append(tmpl.Segments, v.GetSegments()...)
+		param.End = len(tmpl.Segments)
+		tmpl.Params = append(tmpl.Params, param)
+	}
+	for i, seg := range tmpl.Segments {
+		if _, ok := seg.(grammar.DeepWildcard); ok && i != len(tmpl.Segments)-1 {
+			return nil, fmt.Errorf("%s: ** must be the last segment", pattern)
+		}
+	}
+	if tmpl.HasDeepWildcard() {
+		for _, param := range tmpl.Params {
+			if param.End == len(tmpl.Segments) {
+				param.End = -1
+			}
 		}
 	}
-	sort.Sort(&params)
-	return params, nil
+	sort.Sort(&tmpl.Params)
+	return tmpl, nil
+}
+
+// isSingularMessage reports whether field holds a single message that can be
+// addressed directly as arg.Field in generated code.
+func isSingularMessage(field *protogen.Field) bool {
+	if field.Message == nil || field.Desc.IsList() || field.Desc.IsMap() {
+		return false
+	}
+	return field.Oneof == nil || field.Oneof.Desc.IsSynthetic()
+}
+
+// resolveBodyField returns the top level field of the request message named by
+// the HttpRule body. It returns nil for an empty body or "*".
+func resolveBodyField(method *protogen.Method, body string) (*protogen.Field, error) {
+	if body == "" || body == "*" {
+		return nil, nil
+	}
+	for _, field := range method.Input.Fields {
+		if string(field.Desc.Name()) != body {
+			continue
+		}
+		if field.Desc.IsList() && field.Message == nil {
+			return nil, fmt.Errorf("%s: body field %q must not be a repeated scalar", method.Desc.FullName(), body)
+		}
+		return field, nil
+	}
+	return nil, fmt.Errorf("%s: body field %q not found in %s", method.Desc.FullName(), body, method.Input.Desc.FullName())
+}
+
+// resolveResponseField returns the top level field of the response message named
+// by the HttpRule response_body. It returns nil for an empty response_body or "*".
+func resolveResponseField(method *protogen.Method, responseBody string) (*protogen.Field, error) {
+	if responseBody == "" || responseBody == "*" {
+		return nil, nil
+	}
+	for _, field := range method.Output.Fields {
+		if string(field.Desc.Name()) == responseBody {
+			return field, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: response_body field %q not found in %s", method.Desc.FullName(), responseBody, method.Output.Desc.FullName())
+}
+
+// resolvePathParams looks up the request message field bound by each path parameter.
+func resolvePathParams(method *protogen.Method, params []*PathParam) error {
+	for _, param := range params {
+		var (
+			fields  = method.Input.Fields
+			goNames []string
+		)
+		param.Field, param.Parents = nil, nil
+		for _, name := range strings.Split(param.Name, ".") {
+			if param.Field != nil {
+				if !isSingularMessage(param.Field) {
+					return fmt.Errorf("%s: path parameter %q must only traverse singular message fields", method.Desc.FullName(), param.Name)
+				}
+				fields = param.Field.Message.Fields
+				param.Parents = append(param.Parents, param.Field)
+			}
+			param.Field = nil
+			for _, field := range fields {
+				if string(field.Desc.Name()) == name {
+					param.Field = field
+					break
+				}
+			}
+			if param.Field == nil {
+				return fmt.Errorf("%s: path parameter %q not found in %s", method.Desc.FullName(), param.Name, method.Input.Desc.FullName())
+			}
+			goNames = append(goNames, param.Field.GoName)
+		}
+		if param.Field.Desc.IsList() || param.Field.Desc.IsMap() ||
+			(param.Field.Message != nil && !isWrapperMessage(param.Field.Message)) {
+			return fmt.Errorf("%s: path parameter %q must be a scalar, enum or wrapper field", method.Desc.FullName(), param.Name)
+		}
+		param.GoName = strings.Join(goNames, ".")
+		if param.Field.Desc.Kind() != protoreflect.StringKind && (param.End < 0 || param.End-param.Start != 1) {
+			return fmt.Errorf("%s: path parameter %q spanning several segments must be a string field", method.Desc.FullName(), param.Name)
+		}
+	}
+	return nil
+}
+
+// isWrapperMessage reports whether msg is one of the google.protobuf wrapper types.
+func isWrapperMessage(msg *protogen.Message) bool {
+	switch msg.Desc.FullName() {
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue",
+		"google.protobuf.BytesValue":
+		return true
+	}
+	return false
+}
+
+// isQueryLeafMessage reports whether msg is a well-known type given in the query
+// string as a single value rather than field by field.
+func isQueryLeafMessage(msg *protogen.Message) bool {
+	switch msg.Desc.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return true
+	}
+	return isWrapperMessage(msg)
+}
+
+// isBoundParam reports whether the field path name is one of bound or lies below one of them.
+func isBoundParam(name string, bound []string) bool {
+	for _, b := range bound {
+		if name == b || strings.HasPrefix(name, b+".") {
+			return true
+		}
+	}
+	return false
 }
 
 type queryParam struct {
@@ -96,28 +236,56 @@ type queryParam struct {
 
 	GoName string
 	Name   string
+	// Parents are the message fields walked into to reach the field.
+	Parents []*protogen.Field
 }
 
-func createQueryParams(method *protogen.Method) []*queryParam {
+// queryMaxDepth is the default of how many levels of nested messages createQueryParams
+// walks into. It also bounds the walk of recursive messages.
+var queryMaxDepth = 5
+
+// createQueryParams lists the fields of the request message that can be given in the
+// query string. Fields of nested messages are named by their dotted path, down to
+// maxDepth levels of nesting.
+func createQueryParams(method *protogen.Method, maxDepth int) []*queryParam {
 	queryParams := make([]*queryParam, 0)
 
 	var f func(parent *queryParam, fields []*protogen.Field)
 
 	f = func(parent *queryParam, fields []*protogen.Field) {
 		for _, field := range fields {
-			if field.Desc.Kind() == protoreflect.MessageKind {
+			if field.Desc.IsMap() {
+				// Only maps of scalar or enum values can be given in the query string.
+				if field.Desc.MapValue().Kind() == protoreflect.MessageKind {
+					continue
+				}
+				queryParams = append(queryParams, &queryParam{
+					Field:   field,
+					GoName:  fmt.Sprintf("%s%s", parent.GoName, field.GoName),
+					Name:    fmt.Sprintf("%s%s", parent.Name, field.Desc.Name()),
+					Parents: parent.Parents,
+				})
+				continue
+			}
+			if field.Desc.Kind() == protoreflect.MessageKind && !isQueryLeafMessage(field.Message) {
+				if !isSingularMessage(field) || len(parent.Parents) >= maxDepth {
+					// Only singular messages out of a oneof are walked into.
+					continue
+				}
 				q := &queryParam{
-					Field:  field,
-					GoName: fmt.Sprintf("%s.", field.GoName),
-					Name:   fmt.Sprintf("%s.", field.Desc.Name()),
+					Field:   field,
+					GoName:  fmt.Sprintf("%s%s.", parent.GoName, field.GoName),
+					Name:    fmt.Sprintf("%s%s.", parent.Name, field.Desc.Name()),
+					Parents: append(parent.Parents[:len(parent.Parents):len(parent.Parents)], field),
 				}
 				f(q, field.Message.Fields)
 				continue
 			}
 			queryParams = append(queryParams, &queryParam{
-				Field:  field,
-				GoName: fmt.Sprintf("%s%s", parent.GoName, field.GoName),
-				Name:   fmt.Sprintf("%s%s", parent.Name, field.Desc.Name()),
+				Field:   field,
+				GoName:  fmt.Sprintf("%s%s", parent.GoName, field.GoName),
+				Name:    fmt.Sprintf("%s%s", parent.Name, field.Desc.Name()),
+				Parents: parent.Parents,
 			})
 		}
 	}
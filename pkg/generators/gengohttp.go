@@ -1,13 +1,18 @@
 package generators
 
 import (
+		"flag"
 		"fmt"
 		"github.com/weblfe/protoc-gen-api/pkg/app"
+		"github.com/weblfe/protoc-gen-api/pkg/grammar"
 		"google.golang.org/genproto/googleapis/api/annotations"
 		"google.golang.org/protobuf/compiler/protogen"
+		"google.golang.org/protobuf/encoding/protojson"
 		"google.golang.org/protobuf/proto"
 		"google.golang.org/protobuf/reflect/protoreflect"
 		"google.golang.org/protobuf/types/descriptorpb"
+		"net/http"
+		"strconv"
 		"strings"
 )
 
@@ -15,14 +20,16 @@ var (
 	bytesPackage   = protogen.GoImportPath("bytes")
 	contextPackage = protogen.GoImportPath("context")
 	base64Package  = protogen.GoImportPath("encoding/base64")
+	jsonPackage    = protogen.GoImportPath("encoding/json")
+	errorsPackage  = protogen.GoImportPath("errors")
 	fmtPackage     = protogen.GoImportPath("fmt")
 	ioPackage      = protogen.GoImportPath("io")
 	ioutilPackage  = protogen.GoImportPath("io/ioutil")
 	mimePackage    = protogen.GoImportPath("mime")
 	httpPackage    = protogen.GoImportPath("net/http")
+	urlPackage     = protogen.GoImportPath("net/url")
 	strconvPackage = protogen.GoImportPath("strconv")
 	stringsPackage = protogen.GoImportPath("strings")
-	reflectPackage = protogen.GoImportPath("reflect")
 )
 
 var (
@@ -41,8 +48,21 @@ var (
 	timestampPbPackage     = protogen.GoImportPath("google.golang.org/protobuf/types/known/timestamppb")
 	typePbPackage          = protogen.GoImportPath("google.golang.org/protobuf/types/known/typepb")
 	wrappersPbPackage      = protogen.GoImportPath("google.golang.org/protobuf/types/known/wrapperspb")
+	runtimePackage         = protogen.GoImportPath("github.com/weblfe/protoc-gen-api/pkg/runtime")
 )
 
+// jsonMarshalOptions and jsonUnmarshalOptions are the protojson options of the generated
+// handlers, set by the json_* plugin parameters.
+var (
+	jsonMarshalOptions   protojson.MarshalOptions
+	jsonUnmarshalOptions protojson.UnmarshalOptions
+)
+
+// inlineMode generates handlers marshaling and chaining interceptors by themselves
+// instead of calling the runtime package, so the generated code does not depend on
+// this module.
+var inlineMode = false
+
 type apiGenerator struct {
 	name string
 }
@@ -55,6 +75,16 @@ func (a apiGenerator) Generate(plugin *protogen.Plugin, file *protogen.File) (*p
 	return GenerateFile(plugin, file)
 }
 
+// RegisterFlags registers the plugin parameters of the generated http handlers.
+func (a apiGenerator) RegisterFlags(flags *flag.FlagSet) {
+	flags.IntVar(&queryMaxDepth, "query_max_depth", queryMaxDepth, "how many levels of nested messages are bound from the query string")
+	flags.BoolVar(&inlineMode, "inline", inlineMode, "generate handlers not depending on the runtime package")
+	flags.BoolVar(&jsonMarshalOptions.UseProtoNames, "json_use_proto_names", false, "write JSON fields with their proto names")
+	flags.BoolVar(&jsonMarshalOptions.EmitUnpopulated, "json_emit_unpopulated", false, "write JSON fields having their zero value")
+	flags.BoolVar(&jsonMarshalOptions.UseEnumNumbers, "json_use_enum_numbers", false, "write JSON enum values as numbers")
+	flags.BoolVar(&jsonUnmarshalOptions.DiscardUnknown, "json_discard_unknown", false, "ignore unknown JSON fields instead of failing")
+}
+
 func NewApiGenerator() app.Generator {
 	var impl = new(apiGenerator)
 	impl.name = `api-generator`
@@ -98,6 +128,8 @@ func genService(g *protogen.GeneratedFile, srv *protogen.Service) error {
 	genServiceInterface(g, srv)
 	genStruct(g, srv)
 	genConstructor(g, srv)
+	genHTTPStatusError(g, srv)
+	genNegotiate(g, srv)
 
 	for _, method := range srv.Methods {
 		if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
@@ -133,36 +165,94 @@ func methodSignature(g *protogen.GeneratedFile, method *protogen.Method, prefix
 		", interceptors ..." + g.QualifiedGoIdent(grpcPackage.Ident("UnaryServerInterceptor")) + ") "
 }
 
-func genDefaultCallback(g *protogen.GeneratedFile) {
+func genDefaultCallback(g *protogen.GeneratedFile, srv *protogen.Service) {
+	if !inlineMode {
+		g.P("if cb == nil {")
+		g.P("	cb = ", runtimePackage.Ident("DefaultCallback"))
+		g.P("}")
+		return
+	}
 	g.P("if cb == nil {")
 	g.P("	cb = ", callbackSignature(g), " {")
 	g.P("		if err != nil {")
-	g.P("			w.WriteHeader(", httpPackage.Ident("StatusInternalServerError"), ")")
-	g.P("			p := ", statusPackage.Ident("New"), "(", codesPackage.Ident("Unknown"), ", err.Error()).Proto()")
-	g.P("			switch contentType, _, _ := ", mimePackage.Ident("ParseMediaType"), "(r.Header.Get(\"Content-Type\")); contentType {")
-	g.P("				case \"application/protobuf\", \"application/x-protobuf\":")
-	g.P("					buf, err := ", protoPackage.Ident("Marshal"), "(p)")
-	g.P("					if err != nil {")
-	g.P("						return")
-	g.P("					}")
-	g.P("					if _, err := ", ioPackage.Ident("Copy"), "(w, ", bytesPackage.Ident("NewBuffer"), "(buf)); err != nil {")
-	g.P("						return")
-	g.P("					}")
-	g.P("				case \"application/json\":")
-	g.P("					buf, err := ", protojsonPackage.Ident("Marshal"), "(p)")
-	g.P("					if err != nil {")
-	g.P("						return")
-	g.P("					}")
-	g.P("					if _, err := ", ioPackage.Ident("Copy"), "(w, ", bytesPackage.Ident("NewBuffer"), "(buf)); err != nil {")
-	g.P("						return")
-	g.P("					}")
-	g.P("				default:")
+	g.P("			s, ok := ", statusPackage.Ident("FromError"), "(err)")
+	g.P("			var se interface{ GRPCStatus() *", statusPackage.Ident("Status"), " }")
+	g.P("			if !ok && ", errorsPackage.Ident("As"), "(err, &se) {")
+	g.P("				// A wrapped status is found as well.")
+	g.P("				s = se.GRPCStatus()")
+	g.P("			}")
+	genHTTPStatus(g)
+	g.P("			var httpErr *", srv.GoName, "HTTPStatusError")
+	g.P("			if ", errorsPackage.Ident("As"), "(err, &httpErr) {")
+	g.P("				code = httpErr.Status")
+	g.P("			}")
+	g.P("			p := s.Proto()")
+	g.P("")
+	// The error is encoded in the media type of the response, falling back to JSON
+	// when none is acceptable.
+	g.P("			accept := \"application/json\"")
+	g.P("			if _, a, err := h.contentTypes(r); err == nil {")
+	g.P("				accept = a")
+	g.P("			}")
+	g.P("			var buf []byte")
+	g.P("			switch accept {")
+	g.P("			case \"application/protobuf\", \"application/x-protobuf\":")
+	g.P("				buf, err = ", protoPackage.Ident("Marshal"), "(p)")
+	g.P("			default:")
+	g.P("				buf, err = ", protojsonMarshal(g, false), "(p)")
+	g.P("			}")
+	g.P("			if err != nil {")
+	g.P("				w.WriteHeader(code)")
+	g.P("				return")
 	g.P("			}")
+	g.P("			w.Header().Set(\"Content-Type\", accept)")
+	g.P("			w.WriteHeader(code)")
+	g.P("			_, _ = w.Write(buf)")
 	g.P("		}")
 	g.P("	}")
 	g.P("}")
 }
 
+// httpStatusCodes is the HTTP status code of each gRPC status code, following the
+// mapping of google.rpc.Code. The others are mapped to 500 Internal Server Error.
+var httpStatusCodes = []struct {
+	codes  []string
+	status string
+}{
+	{[]string{"OK"}, "StatusOK"},
+	{[]string{"Canceled"}, ""},
+	{[]string{"InvalidArgument", "FailedPrecondition", "OutOfRange"}, "StatusBadRequest"},
+	{[]string{"DeadlineExceeded"}, "StatusGatewayTimeout"},
+	{[]string{"NotFound"}, "StatusNotFound"},
+	{[]string{"AlreadyExists", "Aborted"}, "StatusConflict"},
+	{[]string{"PermissionDenied"}, "StatusForbidden"},
+	{[]string{"Unauthenticated"}, "StatusUnauthorized"},
+	{[]string{"ResourceExhausted"}, "StatusTooManyRequests"},
+	{[]string{"Unimplemented"}, "StatusNotImplemented"},
+	{[]string{"Unavailable"}, "StatusServiceUnavailable"},
+}
+
+// genHTTPStatus emits the HTTP status code mapped from the code of the status s as code,
+// like runtime.HTTPStatusFromCode.
+func genHTTPStatus(g *protogen.GeneratedFile) {
+	g.P("			code := ", httpPackage.Ident("StatusInternalServerError"))
+	g.P("			switch s.Code() {")
+	for _, m := range httpStatusCodes {
+		var cases []string
+		for _, c := range m.codes {
+			cases = append(cases, g.QualifiedGoIdent(codesPackage.Ident(c)))
+		}
+		g.P("			case ", strings.Join(cases, ", "), ":")
+		if m.status == "" {
+			// 499 Client Closed Request is not defined by net/http.
+			g.P("				code = 499")
+			continue
+		}
+		g.P("				code = ", httpPackage.Ident(m.status))
+	}
+	g.P("			}")
+}
+
 func genServiceInterface(g *protogen.GeneratedFile, srv *protogen.Service) {
 	g.P("// ", srv.GoName, "HTTPService is the server API for ", srv.GoName, " service.")
 	g.P("type ", srv.GoName, "HTTPService interface {")
@@ -180,132 +270,211 @@ func genStruct(g *protogen.GeneratedFile, srv *protogen.Service) {
 	g.P("// ", srv.GoName, "HTTPConverter has a function to convert ", srv.GoName, "HTTPService interface to http.HandlerFunc.")
 	g.P("type ", srv.GoName, "HTTPConverter struct {")
 	g.P("srv ", srv.GoName, "HTTPService")
+	if !inlineMode {
+		g.P("marshalers *", runtimePackage.Ident("Registry"))
+	}
 	g.P("}")
 }
 
 func genConstructor(g *protogen.GeneratedFile, srv *protogen.Service) {
 	g.P("// New", srv.GoName, "HTTPConverter returns ", srv.GoName, "HTTPConverter.")
-	g.P("func New", srv.GoName, "HTTPConverter(srv ", srv.GoName, "HTTPService) *", srv.GoName, "HTTPConverter {")
+	if inlineMode {
+		g.P("func New", srv.GoName, "HTTPConverter(srv ", srv.GoName, "HTTPService) *", srv.GoName, "HTTPConverter {")
+	} else {
+		g.P("// The options configure the marshalers of the request and response bodies.")
+		g.P("func New", srv.GoName, "HTTPConverter(srv ", srv.GoName, "HTTPService, opts ...", runtimePackage.Ident("Option"), ") *", srv.GoName, "HTTPConverter {")
+	}
 	g.P("	return &", srv.GoName, "HTTPConverter{")
 	g.P("		srv: srv,")
+	if !inlineMode {
+		var defaults []string
+		if o := marshalOptionsLiteral(g, false); o != "" {
+			defaults = append(defaults, fmt.Sprint(g.QualifiedGoIdent(runtimePackage.Ident("WithMarshalOptions")), "(", o, ")"))
+		}
+		if o := unmarshalOptionsLiteral(g); o != "" {
+			defaults = append(defaults, fmt.Sprint(g.QualifiedGoIdent(runtimePackage.Ident("WithUnmarshalOptions")), "(", o, ")"))
+		}
+		if len(defaults) == 0 {
+			g.P("		marshalers: ", runtimePackage.Ident("NewRegistry"), "(opts...),")
+		} else {
+			// The options given to the constructor override the ones given to the plugin.
+			g.P("		marshalers: ", runtimePackage.Ident("NewRegistry"), "(append([]", runtimePackage.Ident("Option"), "{")
+			for _, d := range defaults {
+				g.P("			", d, ",")
+			}
+			g.P("		}, opts...)...),")
+		}
+	}
 	g.P("	}")
 	g.P("}")
+	if inlineMode {
+		return
+	}
+	g.P()
+	g.P("// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,")
+	g.P("// replacing the one registered before, and returns the ", srv.GoName, "HTTPConverter.")
+	g.P("func (h *", srv.GoName, "HTTPConverter) WithMarshaler(mediaType string, m ", runtimePackage.Ident("Marshaler"), ") *", srv.GoName, "HTTPConverter {")
+	g.P("	h.marshalers.Add(mediaType, m)")
+	g.P("	return h")
+	g.P("}")
 }
 
-func genMethod(g *protogen.GeneratedFile, method *protogen.Method) {
-	g.P("// ", method.GoName, " returns ", method.Parent.GoName, "HTTPService interface's ", method.GoName, " converted to http.HandlerFunc.")
-	if method.Comments.Leading.String() != "" {
-		g.P("//")
+// genHTTPStatusError emits the error type the inline handlers pass to the callback for
+// the errors having an HTTP status code of their own, like runtime.HTTPStatusError.
+func genHTTPStatusError(g *protogen.GeneratedFile, srv *protogen.Service) {
+	if !inlineMode {
+		return
 	}
-	g.P(method.Comments.Leading, methodSignature(g, method, ""), httpPackage.Ident("HandlerFunc"), " {")
-	genDefaultCallback(g)
-	g.P("	return ", httpPackage.Ident("HandlerFunc"), "(func(w ", httpPackage.Ident("ResponseWriter"), ", r *", httpPackage.Ident("Request"), ") {")
-	g.P("		ctx := r.Context()")
-	g.P("")
-	g.P("		contentType, _, _ := ", mimePackage.Ident("ParseMediaType"), "(r.Header.Get(\"Content-Type\"))")
-	g.P("")
-	g.P("		accepts := ", stringsPackage.Ident("Split"), "(r.Header.Get(\"Accept\"), \",\")")
-	g.P("		accept := accepts[0]")
-	g.P("		if accept == \"*/*\" || accept == \"\" {")
-	g.P("			if contentType != \"\" {")
-	g.P("				accept = contentType")
-	g.P("			} else {")
-	g.P("				accept = \"application/json\"")
-	g.P("			}")
+	g.P()
+	g.P("// ", srv.GoName, "HTTPStatusError is an error the default callback responds with Status")
+	g.P("// instead of 500 Internal Server Error.")
+	g.P("type ", srv.GoName, "HTTPStatusError struct {")
+	g.P("	Status int")
+	g.P("	Err    error")
+	g.P("}")
+	g.P()
+	g.P("func (e *", srv.GoName, "HTTPStatusError) Error() string {")
+	g.P("	return e.Err.Error()")
+	g.P("}")
+	g.P()
+	g.P("func (e *", srv.GoName, "HTTPStatusError) Unwrap() error {")
+	g.P("	return e.Err")
+	g.P("}")
+}
+
+// genNegotiate emits the contentTypes method of the converter the inline handlers negotiate
+// the media type of the response with, like runtime.Registry.ContentTypes.
+func genNegotiate(g *protogen.GeneratedFile, srv *protogen.Service) {
+	if !inlineMode {
+		return
+	}
+	g.P()
+	g.P("// contentTypes returns the media type of the request body of r and the media type of")
+	g.P("// the response, negotiated from the Accept header of r as described by RFC 7231")
+	g.P("// section 5.3.2. Media types are preferred by their quality value, then by their order")
+	g.P("// in the Accept header, then in the order of the request media type and application/json.")
+	g.P("// It returns a ", srv.GoName, "HTTPStatusError of 406 Not Acceptable when none is acceptable.")
+	g.P("func (h *", srv.GoName, "HTTPConverter) contentTypes(r *", httpPackage.Ident("Request"), ") (contentType, accept string, err error) {")
+	g.P("	contentType, _, _ = ", mimePackage.Ident("ParseMediaType"), "(r.Header.Get(\"Content-Type\"))")
+	g.P("	mediaTypes := []string{\"application/json\", \"application/protobuf\", \"application/x-protobuf\"}")
+	g.P("	for i, mediaType := range mediaTypes {")
+	g.P("		if mediaType == contentType {")
+	g.P("			copy(mediaTypes[1:i+1], mediaTypes[:i])")
+	g.P("			mediaTypes[0] = contentType")
 	g.P("		}")
-	g.P("")
-	g.P("		w.Header().Set(\"Content-Type\", accept)")
-	g.P("")
-	g.P("		arg := &", genMessageName(method.Input), "{}")
-	g.P("		if r.Method != ", httpPackage.Ident("MethodGet"), " {")
-	g.P("			body, err := ", ioutilPackage.Ident("ReadAll"), "(r.Body)")
+	g.P("	}")
+	g.P()
+	g.P("	header := r.Header.Get(\"Accept\")")
+	g.P("	if ", stringsPackage.Ident("TrimSpace"), "(header) == \"\" {")
+	g.P("		header = \"*/*\"")
+	g.P("	}")
+	g.P("	var bestQ float64")
+	g.P("	var bestIndex int")
+	g.P("	for _, mediaType := range mediaTypes {")
+	g.P("		// The quality value is given by the most specific media range matching mediaType.")
+	g.P("		var q float64")
+	g.P("		var index, specificity int")
+	g.P("		for i, s := range ", stringsPackage.Ident("Split"), "(header, \",\") {")
+	g.P("			mediaRange, params, err := ", mimePackage.Ident("ParseMediaType"), "(s)")
 	g.P("			if err != nil {")
-	g.P("				cb(ctx, w, r, nil, nil, err)")
-	g.P("				return")
+	g.P("				continue")
 	g.P("			}")
-	g.P("")
-	g.P("			switch contentType {")
-	g.P("			case \"application/protobuf\", \"application/x-protobuf\":")
-	g.P("				if err := ", protoPackage.Ident("Unmarshal"), "(body, arg); err != nil {")
-	g.P("					cb(ctx, w, r, nil, nil, err)")
-	g.P("					return")
-	g.P("				}")
-	g.P("			case \"application/json\":")
-	g.P("				if err := ", protojsonPackage.Ident("Unmarshal"), "(body, arg); err != nil {")
-	g.P("					cb(ctx, w, r, nil, nil, err)")
-	g.P("					return")
-	g.P("				}")
-	g.P("			default:")
-	g.P("				w.WriteHeader(", httpPackage.Ident("StatusUnsupportedMediaType"), ")")
-	g.P("				_, err := ", fmtPackage.Ident("Fprintf"), "(w, \"Unsupported Content-Type: %s\", contentType)")
-	g.P("				cb(ctx, w, r, nil, nil, err)")
-	g.P("				return")
+	g.P("			n := 0")
+	g.P("			switch {")
+	g.P("			case mediaRange == mediaType:")
+	g.P("				n = 3")
+	g.P("			case mediaRange != \"*/*\" && ", stringsPackage.Ident("HasSuffix"), "(mediaRange, \"/*\") && ", stringsPackage.Ident("HasPrefix"), "(mediaType, ", stringsPackage.Ident("TrimSuffix"), "(mediaRange, \"*\")):")
+	g.P("				n = 2")
+	g.P("			case mediaRange == \"*/*\":")
+	g.P("				n = 1")
 	g.P("			}")
-	g.P("		}")
-	g.P("")
-	g.P("		n := len(interceptors)")
-	g.P("		chained := func(ctx ", contextPackage.Ident("Context"), ", arg interface{}, info *", grpcPackage.Ident("UnaryServerInfo"), ", handler ", grpcPackage.Ident("UnaryHandler"), ") (interface{}, error) {")
-	g.P("			chainer := func(currentInter ", grpcPackage.Ident("UnaryServerInterceptor"), ", currentHandler ", grpcPackage.Ident("UnaryHandler"), ") ", grpcPackage.Ident("UnaryHandler"), " {")
-	g.P("				return func(currentCtx ", contextPackage.Ident("Context"), ", currentReq interface{}) (interface{}, error) {")
-	g.P("					return currentInter(currentCtx, currentReq, info, currentHandler)")
-	g.P("				}")
+	g.P("			if n <= specificity {")
+	g.P("				continue")
 	g.P("			}")
-	g.P("")
-	g.P("			chainedHandler := handler")
-	g.P("			for i := n - 1; i >= 0; i-- {")
-	g.P("				chainedHandler = chainer(interceptors[i], chainedHandler)")
+	g.P("			v := 1.0")
+	g.P("			if p, ok := params[\"q\"]; ok {")
+	g.P("				if v, err = ", strconvPackage.Ident("ParseFloat"), "(p, 64); err != nil || v < 0 || v > 1 {")
+	g.P("					continue")
+	g.P("				}")
 	g.P("			}")
-	g.P("			return chainedHandler(ctx, arg)")
-	g.P("		}")
-	g.P("")
-	g.P("		info := &", grpcPackage.Ident("UnaryServerInfo"), "{")
-	g.P("			Server:     h.srv,")
-	g.P("			FullMethod: \"/", method.Desc.ParentFile().Package(), ".", method.Parent.GoName, "/", method.GoName, "\",")
+	g.P("			q, index, specificity = v, i, n")
 	g.P("		}")
-	g.P("")
-	g.P("		handler := func(c ", contextPackage.Ident("Context"), ", req interface{}) (interface{}, error) {")
-	g.P("			return h.srv.", method.GoName, "(c, req.(*", genMessageName(method.Input), "))")
+	g.P("		if q > bestQ || (q == bestQ && q > 0 && index < bestIndex) {")
+	g.P("			accept, bestQ, bestIndex = mediaType, q, index")
 	g.P("		}")
-	g.P("")
-	g.P("		iret, err := chained(ctx, arg, info, handler)")
-	g.P("		if err != nil {")
-	g.P("			cb(ctx, w, r, arg, nil, err)")
-	g.P("			return")
+	g.P("	}")
+	g.P("	if bestQ == 0 {")
+	g.P("		return contentType, \"\", &", srv.GoName, "HTTPStatusError{")
+	g.P("			Status: ", httpPackage.Ident("StatusNotAcceptable"), ",")
+	g.P("			Err:    ", fmtPackage.Ident("Errorf"), "(\"Not Acceptable: %s\", header),")
 	g.P("		}")
-	g.P("")
-	g.P("		ret, ok := iret.(*", genMessageName(method.Output), ")")
-	g.P("		if !ok {")
-	g.P("			cb(ctx, w, r, arg, nil, fmt.Errorf(\"/", method.Desc.ParentFile().Package(), ".", method.Parent.GoName, "/", method.GoName, ": interceptors have not return ", genMessageName(method.Output), "\"))")
-	g.P("			return")
+	g.P("	}")
+	g.P("	return contentType, accept, nil")
+	g.P("}")
+}
+
+// marshalOptionsLiteral returns the protojson.MarshalOptions literal of jsonMarshalOptions,
+// or "" when it has no option set. emitUnpopulated sets EmitUnpopulated in addition.
+func marshalOptionsLiteral(g *protogen.GeneratedFile, emitUnpopulated bool) string {
+	var fields []string
+	if jsonMarshalOptions.UseProtoNames {
+		fields = append(fields, "UseProtoNames: true")
+	}
+	if jsonMarshalOptions.EmitUnpopulated || emitUnpopulated {
+		fields = append(fields, "EmitUnpopulated: true")
+	}
+	if jsonMarshalOptions.UseEnumNumbers {
+		fields = append(fields, "UseEnumNumbers: true")
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return fmt.Sprint(g.QualifiedGoIdent(protojsonPackage.Ident("MarshalOptions")), "{", strings.Join(fields, ", "), "}")
+}
+
+// unmarshalOptionsLiteral returns the protojson.UnmarshalOptions literal of
+// jsonUnmarshalOptions, or "" when it has no option set.
+func unmarshalOptionsLiteral(g *protogen.GeneratedFile) string {
+	if !jsonUnmarshalOptions.DiscardUnknown {
+		return ""
+	}
+	return fmt.Sprint(g.QualifiedGoIdent(protojsonPackage.Ident("UnmarshalOptions")), "{DiscardUnknown: true}")
+}
+
+// protojsonMarshal returns the expression of the function encoding JSON in the inline mode.
+func protojsonMarshal(g *protogen.GeneratedFile, emitUnpopulated bool) string {
+	if o := marshalOptionsLiteral(g, emitUnpopulated); o != "" {
+		// The literal is parenthesized as it may be used in an if statement.
+		return "(" + o + ").Marshal"
+	}
+	return g.QualifiedGoIdent(protojsonPackage.Ident("Marshal"))
+}
+
+// protojsonUnmarshal returns the expression of the function decoding JSON in the inline mode.
+func protojsonUnmarshal(g *protogen.GeneratedFile) string {
+	if o := unmarshalOptionsLiteral(g); o != "" {
+		// The literal is parenthesized as it may be used in an if statement.
+		return "(" + o + ").Unmarshal"
+	}
+	return g.QualifiedGoIdent(protojsonPackage.Ident("Unmarshal"))
+}
+
+func genMethod(g *protogen.GeneratedFile, method *protogen.Method) {
+	g.P("// ", method.GoName, " returns ", method.Parent.GoName, "HTTPService interface's ", method.GoName, " converted to http.HandlerFunc.")
+	if method.Comments.Leading.String() != "" {
+		g.P("//")
+	}
+	g.P(method.Comments.Leading, methodSignature(g, method, ""), httpPackage.Ident("HandlerFunc"), " {")
+	genDefaultCallback(g, method.Parent)
+	g.P("	return ", httpPackage.Ident("HandlerFunc"), "(func(w ", httpPackage.Ident("ResponseWriter"), ", r *", httpPackage.Ident("Request"), ") {")
+	genContentTypes(g, true)
+	g.P("		arg := &", genMessageName(method.Input), "{}")
+	g.P("		if r.Method != ", httpPackage.Ident("MethodGet"), " {")
+	genRequestBody(g, method, nil)
 	g.P("		}")
 	g.P("")
-	g.P("		switch accept {")
-	g.P("		case \"application/protobuf\", \"application/x-protobuf\":")
-	g.P("			buf, err := ", protoPackage.Ident("Marshal"), "(ret)")
-	g.P("			if err != nil {")
-	g.P("				cb(ctx, w, r, arg, ret, err)")
-	g.P("				return")
-	g.P("			}")
-	g.P("			if _, err := ", ioPackage.Ident("Copy"), "(w, ", bytesPackage.Ident("NewBuffer"), "(buf)); err != nil {")
-	g.P("				cb(ctx, w, r, arg, ret, err)")
-	g.P("				return")
-	g.P("			}")
-	g.P("		case \"application/json\":")
-	g.P("			buf, err := ", protojsonPackage.Ident("Marshal"), "(ret)")
-	g.P("			if err != nil {")
-	g.P("				cb(ctx, w, r, arg, ret, err)")
-	g.P("				return")
-	g.P("			}")
-	g.P("			if _, err := ", ioPackage.Ident("Copy"), "(w, ", bytesPackage.Ident("NewBuffer"), "(buf)); err != nil {")
-	g.P("				cb(ctx, w, r, arg, ret, err)")
-	g.P("				return")
-	g.P("			}")
-	g.P("		default:")
-	g.P("			w.WriteHeader(", httpPackage.Ident("StatusUnsupportedMediaType"), ")")
-	g.P("			_, err := fmt.Fprintf(w, \"Unsupported Accept: %s\", accept)")
-	g.P("			cb(ctx, w, r, arg, ret, err)")
-	g.P("			return")
-	g.P("		}")
+	genInvoke(g, method)
+	genResponseBody(g, nil)
 	g.P("		cb(ctx, w, r, arg, ret, nil)")
 	g.P("	})")
 	g.P("}")
@@ -332,6 +501,26 @@ func genMethodHTTPRule(g *protogen.GeneratedFile, method *protogen.Method) error
 		return nil
 	}
 
+	if err := genHTTPRule(g, method, httpRule, "HTTPRule", ""); err != nil {
+		return err
+	}
+	for i, binding := range httpRule.GetAdditionalBindings() {
+		if len(binding.GetAdditionalBindings()) != 0 {
+			return fmt.Errorf("%s: additional_bindings must not be nested", method.Desc.FullName())
+		}
+		var (
+			suffix = fmt.Sprintf("HTTPRule%d", i+1)
+			note   = fmt.Sprintf(" for additional binding %d", i+1)
+		)
+		if err := genHTTPRule(g, method, binding, suffix, note); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// genHTTPRule generates the method named method.GoName+suffix serving a single HttpRule binding.
+func genHTTPRule(g *protogen.GeneratedFile, method *protogen.Method, httpRule *annotations.HttpRule, suffix, note string) error {
 	var (
 		httpMethod string
 		pattern    string
@@ -353,95 +542,147 @@ func genMethodHTTPRule(g *protogen.GeneratedFile, method *protogen.Method) error
 	case *annotations.HttpRule_Patch:
 		httpMethod = "http.MethodPatch"
 		pattern = httpRule.GetPatch()
+	case *annotations.HttpRule_Custom:
+		if httpRule.GetCustom().GetKind() == "" {
+			return fmt.Errorf("%s: custom pattern has no kind", method.Desc.FullName())
+		}
+		httpMethod = customHTTPMethod(httpRule.GetCustom().GetKind())
+		pattern = httpRule.GetCustom().GetPath()
 	default:
 		return nil
 	}
 
-	pathParams, err := parsePathParam(pattern)
+	tmpl, err := parsePathTemplate(pattern)
+	if err != nil {
+		return err
+	}
+	if err := resolvePathParams(method, tmpl.Params); err != nil {
+		return err
+	}
+
+	bodyField, err := resolveBodyField(method, httpRule.GetBody())
+	if err != nil {
+		return err
+	}
+
+	responseField, err := resolveResponseField(method, httpRule.GetResponseBody())
 	if err != nil {
 		return err
 	}
 
-	queryParams := createQueryParams(method)
+	queryParams := createQueryParams(method, queryMaxDepth)
 
-	g.P("// ", method.GoName, "HTTPRule returns HTTP method, path and ", method.Parent.GoName, "HTTPService interface's ", method.GoName, " converted to http.HandlerFunc.")
+	g.P("// ", method.GoName, suffix, " returns HTTP method, path and ", method.Parent.GoName, "HTTPService interface's ", method.GoName, " converted to http.HandlerFunc", note, ".")
 	if method.Comments.Leading.String() != "" {
 		g.P("//")
 	}
-	g.P(method.Comments.Leading, methodSignature(g, method, "HTTPRule"), " (string, string, ", httpPackage.Ident("HandlerFunc"), ") {")
-	genDefaultCallback(g)
+	g.P(method.Comments.Leading, methodSignature(g, method, suffix), " (string, string, ", httpPackage.Ident("HandlerFunc"), ") {")
+	genDefaultCallback(g, method.Parent)
 	g.P("	return ", httpMethod, ", \"", pattern, "\", ", httpPackage.Ident("HandlerFunc"), "(func(w ", httpPackage.Ident("ResponseWriter"), ", r *", httpPackage.Ident("Request"), ") {")
-	g.P("		ctx := r.Context()")
-	g.P("")
-	g.P("		contentType, _, _ := ", mimePackage.Ident("ParseMediaType"), "(r.Header.Get(\"Content-Type\"))")
-	g.P("")
-	g.P("		accepts := ", stringsPackage.Ident("Split"), "(r.Header.Get(\"Accept\"), \",\")")
-	g.P("		accept := accepts[0]")
-	g.P("		if accept == \"*/*\" || accept == \"\" {")
-	g.P("			if contentType != \"\" {")
-	g.P("				accept = contentType")
-	g.P("			} else {")
-	g.P("				accept = \"application/json\"")
-	g.P("			}")
-	g.P("		}")
-	g.P("")
-	g.P("		w.Header().Set(\"Content-Type\", accept)")
-	g.P("")
+	_, isGet := httpRule.GetPattern().(*annotations.HttpRule_Get)
+	hasBody := !isGet && httpRule.GetBody() != ""
+	genContentTypes(g, hasBody)
 	g.P("		arg := &", genMessageName(method.Input), "{}")
-	if _, ok := httpRule.GetPattern().(*annotations.HttpRule_Get); ok {
-		g.P("if r.Method == http.MethodGet {")
+	genPathMatch(g, tmpl, pattern)
+	g.P("")
+	if hasBody {
+		genRequestBody(g, method, bodyField)
+		g.P("")
+	}
+	if httpRule.GetBody() != "*" {
+		// Fields bound neither by the path nor by the body are query parameters.
+		bound := make([]string, 0, len(tmpl.Params)+1)
+		for _, param := range tmpl.Params {
+			bound = append(bound, param.Name)
+		}
+		if bodyField != nil {
+			bound = append(bound, string(bodyField.Desc.Name()))
+		}
 		for _, p := range queryParams {
-			for _, pattern := range pathParams {
-				if p.GoName == pattern.GoName {
-					goto Pass
-				}
+			if !isBoundParam(p.Name, bound) {
+				genQueryString(g, p)
 			}
-			genQueryString(g, p)
-		Pass:
 		}
-		g.P("}")
-	} else {
-		g.P("		if r.Method != ", httpPackage.Ident("MethodGet"), " {")
-		g.P("			body, err := ", ioutilPackage.Ident("ReadAll"), "(r.Body)")
-		g.P("			if err != nil {")
-		g.P("				cb(ctx, w, r, nil, nil, err)")
-		g.P("				return")
-		g.P("			}")
 		g.P("")
-		g.P("			switch contentType {")
-		g.P("			case \"application/protobuf\", \"application/x-protobuf\":")
-		g.P("				if err := ", protoPackage.Ident("Unmarshal"), "(body, arg); err != nil {")
-		g.P("					cb(ctx, w, r, nil, nil, err)")
-		g.P("					return")
-		g.P("				}")
-		g.P("			case \"application/json\":")
-		g.P("				if err := ", protojsonPackage.Ident("Unmarshal"), "(body, arg); err != nil {")
-		g.P("					cb(ctx, w, r, nil, nil, err)")
-		g.P("					return")
-		g.P("				}")
-		g.P("			default:")
-		g.P("				w.WriteHeader(", httpPackage.Ident("StatusUnsupportedMediaType"), ")")
-		g.P("				_, err := ", fmtPackage.Ident("Fprintf"), "(w, \"Unsupported Content-Type: %s\", contentType)")
-		g.P("				cb(ctx, w, r, nil, nil, err)")
-		g.P("				return")
-		g.P("			}")
-		g.P("		}")
 	}
+	for _, t := range tmpl.Params {
+		genPathParam(g, t)
+	}
+
 	g.P("")
+	genInvoke(g, method)
+	genResponseBody(g, responseField)
+	g.P("		cb(ctx, w, r, arg, ret, nil)")
+	g.P("	})")
+	g.P("}")
 
-	if len(pathParams) != 0 {
-		g.P("p := strings.Split(r.URL.Path, \"/\")")
-	}
+	return nil
+}
 
-	for _, t := range pathParams {
-		for _, p := range t.GetGoNamesWithSplit() {
-			g.P(reflectPackage.Ident("ValueOf"), "(&arg.", p, ").Elem().Set(", reflectPackage.Ident("ValueOf"), "(", reflectPackage.Ident("New"), "(", reflectPackage.Ident("TypeOf"), "(arg.", p, ").Elem()).Interface()))")
+// genContentTypes emits the context of the request as ctx, and the media types of the
+// request body and of the response as contentType and accept. contentType is left out
+// without a request body. In the runtime mode w is wrapped to track the response. A
+// request accepting no supported media type is given to the callback before the service
+// is invoked.
+func genContentTypes(g *protogen.GeneratedFile, hasBody bool) {
+	if !inlineMode {
+		g.P("		w = ", runtimePackage.Ident("NewResponseWriter"), "(w)")
+		g.P("		ctx := ", runtimePackage.Ident("NewContext"), "(r.Context(), h.marshalers)")
+		g.P("")
+		contentType := "_"
+		if hasBody {
+			contentType = "contentType"
 		}
-
-		g.P("arg.", t.GoName, " = p[", t.Index, "]")
+		g.P("		", contentType, ", accept, err := h.marshalers.ContentTypes(r)")
+		g.P("		if err != nil {")
+		g.P("			cb(ctx, w, r, nil, nil, err)")
+		g.P("			return")
+		g.P("		}")
+		g.P("")
+		return
 	}
-
+	g.P("		ctx := r.Context()")
 	g.P("")
+	contentType := "_"
+	if hasBody {
+		contentType = "contentType"
+	}
+	g.P("		", contentType, ", accept, err := h.contentTypes(r)")
+	g.P("		if err != nil {")
+	g.P("			cb(ctx, w, r, nil, nil, err)")
+	g.P("			return")
+	g.P("		}")
+	g.P("")
+}
+
+// genInvoke emits the call of the method of the service through the interceptors,
+// leaving its response in ret. In the runtime mode the metadata set by the service
+// is written to the header and the trailers of the response.
+func genInvoke(g *protogen.GeneratedFile, method *protogen.Method) {
+	if !inlineMode {
+		g.P("		info := &", grpcPackage.Ident("UnaryServerInfo"), "{")
+		g.P("			Server:     h.srv,")
+		g.P("			FullMethod: \"/", method.Desc.ParentFile().Package(), ".", method.Parent.GoName, "/", method.GoName, "\",")
+		g.P("		}")
+		g.P("		handler := func(c ", contextPackage.Ident("Context"), ", req interface{}) (interface{}, error) {")
+		g.P("			return h.srv.", method.GoName, "(c, req.(*", genMessageName(method.Input), "))")
+		g.P("		}")
+		g.P("		ctx, stream := ", runtimePackage.Ident("NewServerTransportStream"), "(ctx, info.FullMethod)")
+		g.P("		iret, err := ", runtimePackage.Ident("ChainUnaryServer"), "(interceptors...)(ctx, arg, info, handler)")
+		g.P("		h.marshalers.WriteMetadata(w, stream)")
+		g.P("		if err != nil {")
+		g.P("			cb(ctx, w, r, arg, nil, err)")
+		g.P("			return")
+		g.P("		}")
+		g.P("")
+		g.P("		ret, ok := iret.(*", genMessageName(method.Output), ")")
+		g.P("		if !ok {")
+		g.P("			cb(ctx, w, r, arg, nil, ", interceptorError(g, method), ")")
+		g.P("			return")
+		g.P("		}")
+		g.P("")
+		return
+	}
 	g.P("		n := len(interceptors)")
 	g.P("		chained := func(ctx ", contextPackage.Ident("Context"), ", arg interface{}, info *", grpcPackage.Ident("UnaryServerInfo"), ", handler ", grpcPackage.Ident("UnaryHandler"), ") (interface{}, error) {")
 	g.P("			chainer := func(currentInter ", grpcPackage.Ident("UnaryServerInterceptor"), ", currentHandler ", grpcPackage.Ident("UnaryHandler"), ") ", grpcPackage.Ident("UnaryHandler"), " {")
@@ -474,253 +715,508 @@ func genMethodHTTPRule(g *protogen.GeneratedFile, method *protogen.Method) error
 	g.P("")
 	g.P("		ret, ok := iret.(*", genMessageName(method.Output), ")")
 	g.P("		if !ok {")
-	g.P("			cb(ctx, w, r, arg, nil, fmt.Errorf(\"/", method.Desc.ParentFile().Package(), ".", method.Parent.GoName, "/", method.GoName, ": interceptors have not return ", genMessageName(method.Output), "\"))")
+	g.P("			cb(ctx, w, r, arg, nil, ", interceptorError(g, method), ")")
 	g.P("			return")
 	g.P("		}")
 	g.P("")
+}
+
+// interceptorError returns the expression of the error of the interceptors returning
+// another type than the response message of method. It is a codes.Internal status as
+// the server is at fault.
+func interceptorError(g *protogen.GeneratedFile, method *protogen.Method) string {
+	return fmt.Sprint(g.QualifiedGoIdent(statusPackage.Ident("Errorf")), "(", g.QualifiedGoIdent(codesPackage.Ident("Internal")), ", \"/",
+		method.Desc.ParentFile().Package(), ".", method.Parent.GoName, "/", method.GoName, ": interceptors have not return ", g.QualifiedGoIdent(genMessageName(method.Output)), "\")")
+}
+
+// customHTTPMethod returns the expression of the HTTP method for the kind of a custom pattern.
+func customHTTPMethod(kind string) string {
+	switch kind {
+	case http.MethodGet:
+		return "http.MethodGet"
+	case http.MethodHead:
+		return "http.MethodHead"
+	case http.MethodPost:
+		return "http.MethodPost"
+	case http.MethodPut:
+		return "http.MethodPut"
+	case http.MethodPatch:
+		return "http.MethodPatch"
+	case http.MethodDelete:
+		return "http.MethodDelete"
+	case http.MethodConnect:
+		return "http.MethodConnect"
+	case http.MethodOptions:
+		return "http.MethodOptions"
+	case http.MethodTrace:
+		return "http.MethodTrace"
+	default:
+		return strconv.Quote(kind)
+	}
+}
+
+// genRequestBody reads the request body and decodes it into arg.
+// When field is not nil only that field of arg is populated from the body,
+// as selected by the HttpRule body field.
+func genRequestBody(g *protogen.GeneratedFile, method *protogen.Method, field *protogen.Field) {
+	if !inlineMode {
+		var name string
+		if field != nil {
+			name = string(field.Desc.Name())
+		}
+		g.P("			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, \"", name, "\"); err != nil {")
+		g.P("				cb(ctx, w, r, nil, nil, err)")
+		g.P("				return")
+		g.P("			}")
+		return
+	}
+
+	var (
+		target   = "arg"
+		jsonBody = "body"
+	)
+
+	var name string
+	if field != nil {
+		name = strconv.Quote(string(field.Desc.Name()))
+	}
+	g.P("			body, err := ", ioutilPackage.Ident("ReadAll"), "(r.Body)")
+	g.P("			if err != nil {")
+	g.P("				cb(ctx, w, r, nil, nil, ", bindingError(g, "body", name, "err"), ")")
+	g.P("				return")
+	g.P("			}")
+	g.P("")
+	switch {
+	case field == nil:
+	case isSingularMessage(field):
+		target = "arg." + field.GoName
+		g.P("			", target, " = &", genMessageName(field.Message), "{}")
+	default:
+		// Non-message fields are decoded through a copy of the request message,
+		// so the JSON body is wrapped as the value of the field.
+		target = "tmp"
+		jsonBody = fmt.Sprintf("append(append([]byte(%q), body...), '}')", fmt.Sprintf("{%q:", field.Desc.Name()))
+		g.P("			tmp := &", genMessageName(method.Input), "{}")
+	}
+	g.P("			switch contentType {")
+	g.P("			case \"application/protobuf\", \"application/x-protobuf\":")
+	g.P("				if err := ", protoPackage.Ident("Unmarshal"), "(body, ", target, "); err != nil {")
+	g.P("					cb(ctx, w, r, nil, nil, ", bindingError(g, "body", name, "err"), ")")
+	g.P("					return")
+	g.P("				}")
+	g.P("			case \"application/json\":")
+	g.P("				if err := ", protojsonUnmarshal(g), "(", jsonBody, ", ", target, "); err != nil {")
+	g.P("					cb(ctx, w, r, nil, nil, ", bindingError(g, "body", name, "err"), ")")
+	g.P("					return")
+	g.P("				}")
+	g.P("			default:")
+	g.P("				cb(ctx, w, r, nil, nil, &", method.Parent.GoName, "HTTPStatusError{")
+	g.P("					Status: ", httpPackage.Ident("StatusUnsupportedMediaType"), ",")
+	g.P("					Err:    ", fmtPackage.Ident("Errorf"), "(\"Unsupported Content-Type: %s\", contentType),")
+	g.P("				})")
+	g.P("				return")
+	g.P("			}")
+	if target == "tmp" {
+		g.P("			if fd := tmp.ProtoReflect().Descriptor().Fields().ByName(\"", field.Desc.Name(), "\"); tmp.ProtoReflect().Has(fd) {")
+		g.P("				arg.ProtoReflect().Set(fd, tmp.ProtoReflect().Get(fd))")
+		g.P("			}")
+	}
+}
+
+// genResponseBody writes ret in the format requested by accept.
+// When field is not nil only that field of ret is written,
+// as selected by the HttpRule response_body field.
+func genResponseBody(g *protogen.GeneratedFile, field *protogen.Field) {
+	if !inlineMode {
+		var name string
+		if field != nil {
+			name = string(field.Desc.Name())
+		}
+		g.P("		if err := h.marshalers.WriteResponse(w, accept, ret, \"", name, "\"); err != nil {")
+		g.P("			cb(ctx, w, r, arg, ret, err)")
+		g.P("			return")
+		g.P("		}")
+		return
+	}
+
+	var (
+		source  = "ret"
+		extract = false
+	)
+
+	switch {
+	case field == nil:
+	case isSingularMessage(field):
+		source = "ret.Get" + field.GoName + "()"
+	default:
+		// Other fields are written through a copy of ret holding only that field.
+		source = "res.Interface()"
+		extract = true
+		g.P("		res := ret.ProtoReflect().New()")
+		g.P("		if fd := res.Descriptor().Fields().ByName(\"", field.Desc.Name(), "\"); ret.ProtoReflect().Has(fd) {")
+		g.P("			res.Set(fd, ret.ProtoReflect().Get(fd))")
+		g.P("		}")
+		g.P("")
+	}
 	g.P("		switch accept {")
 	g.P("		case \"application/protobuf\", \"application/x-protobuf\":")
-	g.P("			buf, err := ", protoPackage.Ident("Marshal"), "(ret)")
+	g.P("			buf, err := ", protoPackage.Ident("Marshal"), "(", source, ")")
 	g.P("			if err != nil {")
 	g.P("				cb(ctx, w, r, arg, ret, err)")
 	g.P("				return")
 	g.P("			}")
+	g.P("			w.Header().Set(\"Content-Type\", accept)")
 	g.P("			if _, err := ", ioPackage.Ident("Copy"), "(w, ", bytesPackage.Ident("NewBuffer"), "(buf)); err != nil {")
 	g.P("				cb(ctx, w, r, arg, ret, err)")
 	g.P("				return")
 	g.P("			}")
 	g.P("		case \"application/json\":")
-	g.P("			buf, err := ", protojsonPackage.Ident("Marshal"), "(ret)")
-	g.P("			if err != nil {")
-	g.P("				cb(ctx, w, r, arg, ret, err)")
-	g.P("				return")
-	g.P("			}")
+	switch {
+	case field != nil && !extract:
+		// An unset message field is written as null rather than as an empty message.
+		g.P("			buf := []byte(\"null\")")
+		g.P("			if ", source, " != nil {")
+		g.P("				var err error")
+		g.P("				if buf, err = ", protojsonMarshal(g, false), "(", source, "); err != nil {")
+		g.P("					cb(ctx, w, r, arg, ret, err)")
+		g.P("					return")
+		g.P("				}")
+		g.P("			}")
+	case !extract || field.Desc.IsList() || field.Desc.IsMap():
+		g.P("			buf, err := ", protojsonMarshal(g, false), "(", source, ")")
+	default:
+		g.P("			buf, err := ", protojsonMarshal(g, true), "(", source, ")")
+	}
+	if field == nil || extract {
+		g.P("			if err != nil {")
+		g.P("				cb(ctx, w, r, arg, ret, err)")
+		g.P("				return")
+		g.P("			}")
+	}
+	if extract {
+		empty := "null"
+		switch {
+		case field.Desc.IsList():
+			empty = "[]"
+		case field.Desc.IsMap():
+			empty = "{}"
+		}
+		g.P("			var fields map[string]", jsonPackage.Ident("RawMessage"))
+		g.P("			if err := ", jsonPackage.Ident("Unmarshal"), "(buf, &fields); err != nil {")
+		g.P("				cb(ctx, w, r, arg, ret, err)")
+		g.P("				return")
+		g.P("			}")
+		name := field.Desc.JSONName()
+		if jsonMarshalOptions.UseProtoNames {
+			name = string(field.Desc.Name())
+		}
+		g.P("			buf, ok := fields[\"", name, "\"]")
+		g.P("			if !ok {")
+		g.P("				buf = []byte(\"", empty, "\")")
+		g.P("			}")
+	}
+	g.P("			w.Header().Set(\"Content-Type\", accept)")
 	g.P("			if _, err := ", ioPackage.Ident("Copy"), "(w, ", bytesPackage.Ident("NewBuffer"), "(buf)); err != nil {")
 	g.P("				cb(ctx, w, r, arg, ret, err)")
 	g.P("				return")
 	g.P("			}")
-	g.P("		default:")
-	g.P("			w.WriteHeader(", httpPackage.Ident("StatusUnsupportedMediaType"), ")")
-	g.P("			_, err := fmt.Fprintf(w, \"Unsupported Accept: %s\", accept)")
-	g.P("			cb(ctx, w, r, arg, ret, err)")
-	g.P("			return")
 	g.P("		}")
-	g.P("		cb(ctx, w, r, arg, ret, nil)")
-	g.P("	})")
+}
+
+func genQueryString(g *protogen.GeneratedFile, queryParam *queryParam) {
+	fail := func(err string) {
+		g.P("cb(ctx, w, r, nil, nil, ", bindingError(g, "query", strconv.Quote(queryParam.Name), err), ")")
+		g.P("return")
+	}
+	if queryParam.Desc.IsMap() {
+		genMapQueryString(g, queryParam)
+		return
+	}
+	if queryParam.Desc.IsList() {
+		g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
+		g.P("	arr := make([]", goType(g, queryParam.Field), ", 0, len(repeated))")
+		g.P("	for _, v := range repeated {")
+		c := genConvert(g, queryParam.Field, "v", fail)
+		g.P("		arr = append(arr, ", c, ")")
+		g.P("	}")
+		genMessageAlloc(g, queryParam.Parents)
+		g.P("	arg.", queryParam.GoName, " = arr")
+		g.P("}")
+	} else {
+		g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
+		c := genConvert(g, queryParam.Field, "v", fail)
+		genMessageAlloc(g, queryParam.Parents)
+		genFieldAssign(g, queryParam.GoName, queryParam.Field, c, fail)
+		g.P("}")
+	}
+}
+
+// bindingError returns the expression of the error of a field failing to be bound from
+// source, one of "path", "query" and "body". field is the expression of the name of the
+// field, "" for the whole body, and err the expression of the cause.
+func bindingError(g *protogen.GeneratedFile, source, field, err string) string {
+	if !inlineMode {
+		if field == "" {
+			field = `""`
+		}
+		return fmt.Sprint("&", g.QualifiedGoIdent(runtimePackage.Ident("BindingError")), "{Source: ",
+			g.QualifiedGoIdent(runtimePackage.Ident("Source"+toCamelCase(source))), ", Field: ", field, ", Err: ", err, "}")
+	}
+	// The messages are the ones of runtime.BindingError.
+	var format string
+	switch {
+	case field == "":
+		return fmt.Sprint(g.QualifiedGoIdent(statusPackage.Ident("Errorf")), "(", g.QualifiedGoIdent(codesPackage.Ident("InvalidArgument")), ", \"invalid request ", source, ": %v\", ", err, ")")
+	case source == "body":
+		format = "invalid body field %q: %v"
+	default:
+		format = "invalid " + source + " parameter %q: %v"
+	}
+	return fmt.Sprint(g.QualifiedGoIdent(statusPackage.Ident("Errorf")), "(", g.QualifiedGoIdent(codesPackage.Ident("InvalidArgument")), ", ", strconv.Quote(format), ", ", field, ", ", err, ")")
+}
+
+// genMessageAlloc allocates each message of parents, from the outermost one, which is
+// still nil in arg.
+func genMessageAlloc(g *protogen.GeneratedFile, parents []*protogen.Field) {
+	var goName string
+	for _, parent := range parents {
+		goName += parent.GoName
+		g.P("if arg.", goName, " == nil {")
+		g.P("	arg.", goName, " = &", genMessageName(parent.Message), "{}")
+		g.P("}")
+		goName += "."
+	}
+}
+
+// genMapQueryString binds the query parameters like name[key]=value to the map field.
+// The last value is used when a key is given several times.
+func genMapQueryString(g *protogen.GeneratedFile, queryParam *queryParam) {
+	keyField, valueField := queryParam.Message.Fields[0], queryParam.Message.Fields[1]
+	fail := func(err string) {
+		g.P("cb(ctx, w, r, nil, nil, ", bindingError(g, "query", "k", err), ")")
+		g.P("return")
+	}
+	g.P("for k, vs := range r.URL.Query() {")
+	g.P("	if len(vs) == 0 || !", stringsPackage.Ident("HasPrefix"), "(k, \"", queryParam.Name, "[\") || !", stringsPackage.Ident("HasSuffix"), "(k, \"]\") {")
+	g.P("		continue")
+	g.P("	}")
+	g.P("	if k == \"", queryParam.Name, "[]\" {")
+	fail(fmt.Sprint(g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), "(\"empty key of map ", queryParam.Name, "\")"))
+	g.P("	}")
+	genMessageAlloc(g, queryParam.Parents)
+	g.P("	if arg.", queryParam.GoName, " == nil {")
+	g.P("		arg.", queryParam.GoName, " = make(map[", goType(g, keyField), "]", goType(g, valueField), ")")
+	g.P("	}")
+	c := genConvert(g, keyField, fmt.Sprintf("k[%d:len(k)-1]", len(queryParam.Name)+1), fail)
+	g.P("	key := ", c)
+	g.P("	{")
+	g.P("		v := vs[len(vs)-1]")
+	c = genConvert(g, valueField, "v", fail)
+	g.P("		arg.", queryParam.GoName, "[key] = ", c)
+	g.P("	}")
 	g.P("}")
+}
 
-	return nil
+// genPathMatch splits the escaped request path into p and checks it against the verb,
+// the literal segments and the number of segments of tmpl.
+func genPathMatch(g *protogen.GeneratedFile, tmpl *PathTemplate, pattern string) {
+	var cond []string
+	if tmpl.HasDeepWildcard() {
+		cond = append(cond, fmt.Sprintf("len(p) < %d", tmpl.MinLen()))
+	} else {
+		cond = append(cond, fmt.Sprintf("len(p) != %d", tmpl.MinLen()))
+	}
+	for i, seg := range tmpl.Segments {
+		if l, ok := seg.(grammar.Literal); ok {
+			cond = append(cond, fmt.Sprintf("p[%d] != %q", i, string(l)))
+		}
+	}
+	notFound := func() {
+		g.P("	cb(ctx, w, r, nil, nil, ", statusPackage.Ident("Errorf"), "(", codesPackage.Ident("NotFound"), ", \"path %q does not match %q\", r.URL.Path, \"", pattern, "\"))")
+		g.P("	return")
+	}
+
+	g.P("path := ", stringsPackage.Ident("TrimPrefix"), "(r.URL.EscapedPath(), \"/\")")
+	if tmpl.Verb != "" {
+		g.P("if !", stringsPackage.Ident("HasSuffix"), "(path, \":", tmpl.Verb, "\") {")
+		notFound()
+		g.P("}")
+		g.P("path = ", stringsPackage.Ident("TrimSuffix"), "(path, \":", tmpl.Verb, "\")")
+	}
+	g.P("p := ", stringsPackage.Ident("Split"), "(path, \"/\")")
+	g.P("if ", strings.Join(cond, " || "), " {")
+	notFound()
+	g.P("}")
 }
 
-func genQueryString(g *protogen.GeneratedFile, queryParam *queryParam) {
-	switch queryParam.Desc.Kind() {
+// genPathParam unescapes the path segments captured by param and assigns them to its field.
+// A variable of a single segment is fully unescaped, while "%2F" is left unchanged
+// in a variable spanning several segments.
+func genPathParam(g *protogen.GeneratedFile, param *PathParam) {
+	var src string
+	switch {
+	case param.End < 0:
+		src = fmt.Sprint(g.QualifiedGoIdent(stringsPackage.Ident("Join")), "(p[", param.Start, ":], \"/\")")
+	case param.End-param.Start == 1:
+		src = fmt.Sprintf("p[%d]", param.Start)
+	default:
+		src = fmt.Sprint(g.QualifiedGoIdent(stringsPackage.Ident("Join")), "(p[", param.Start, ":", param.End, "], \"/\")")
+	}
+	if param.End < 0 || param.End-param.Start != 1 {
+		src = fmt.Sprint(g.QualifiedGoIdent(stringsPackage.Ident("NewReplacer")), "(\"%2F\", \"%252F\", \"%2f\", \"%252f\").Replace(", src, ")")
+	}
+
+	fail := func(err string) {
+		g.P("cb(ctx, w, r, nil, nil, ", bindingError(g, "path", strconv.Quote(param.Name), err), ")")
+		g.P("return")
+	}
+	g.P("if v, err := ", urlPackage.Ident("PathUnescape"), "(", src, "); err != nil {")
+	fail("err")
+	if param.Field.Desc.Kind() == protoreflect.StringKind {
+		g.P("} else {")
+		genMessageAlloc(g, param.Parents)
+		genFieldAssign(g, param.GoName, param.Field, "v", fail)
+		g.P("}")
+		return
+	}
+	g.P("} else if v != \"\" {")
+	c := genConvert(g, param.Field, "v", fail)
+	genMessageAlloc(g, param.Parents)
+	genFieldAssign(g, param.GoName, param.Field, c, fail)
+	g.P("}")
+}
+
+// genFieldAssign assigns value to the field of arg at goName. A member of a oneof is
+// assigned through its wrapper type, and fail is called when another member of the
+// oneof is already set.
+func genFieldAssign(g *protogen.GeneratedFile, goName string, field *protogen.Field, value string, fail func(err string)) {
+	if field.Oneof == nil || field.Oneof.Desc.IsSynthetic() {
+		g.P("arg.", goName, " = ", genPointer(g, field, value))
+		return
+	}
+	oneof := "arg." + strings.TrimSuffix(goName, field.GoName) + field.Oneof.GoName
+	g.P("if _, ok := ", oneof, ".(*", field.GoIdent, "); ", oneof, " != nil && !ok {")
+	fail(fmt.Sprint(g.QualifiedGoIdent(fmtPackage.Ident("Errorf")), "(\"another field of oneof ", field.Oneof.Desc.Name(), " is already set\")"))
+	g.P("}")
+	g.P(oneof, " = &", field.GoIdent, "{", field.GoName, ": ", value, "}")
+}
+
+// genPointer returns the expression of a pointer to value when field is a proto3
+// optional field held by a pointer, or value itself otherwise.
+func genPointer(g *protogen.GeneratedFile, field *protogen.Field, value string) string {
+	if !field.Desc.HasOptionalKeyword() || field.Desc.Syntax() != protoreflect.Proto3 {
+		return value
+	}
+	var ptr string
+	switch field.Desc.Kind() {
 	case protoreflect.BoolKind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([]bool, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		c, err := ", strconvPackage.Ident("ParseBool"), "(v)")
-			g.P("		if err != nil {")
-			g.P("			cb(ctx, w, r, nil, nil, err)")
-			g.P("			return")
-			g.P("		}")
-			g.P("		arr = append(arr, c)")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	c, err := ", strconvPackage.Ident("ParseBool"), "(v)")
-			g.P("	if err != nil {")
-			g.P("		cb(ctx, w, r, nil, nil, err)")
-			g.P("		return")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = c")
-			g.P("}")
-		}
+		ptr = "Bool"
+	case protoreflect.EnumKind:
+		return fmt.Sprint(value, ".Enum()")
 	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([]int32, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		c, err := ", strconvPackage.Ident("ParseInt"), "(v, 10, 32)")
-			g.P("		if err != nil {")
-			g.P("			cb(ctx, w, r, nil, nil, err)")
-			g.P("			return")
-			g.P("		}")
-			g.P("		arr = append(arr, int32(c))")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	c, err := ", strconvPackage.Ident("ParseInt"), "(v, 10, 32)")
-			g.P("	if err != nil {")
-			g.P("		cb(ctx, w, r, nil, nil, err)")
-			g.P("		return")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = int32(c)")
-			g.P("}")
-		}
+		ptr = "Int32"
 	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([]uint32, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		c, err := ", strconvPackage.Ident("ParseUint"), "(v, 10, 32)")
-			g.P("		if err != nil {")
-			g.P("			cb(ctx, w, r, nil, nil, err)")
-			g.P("			return")
-			g.P("		}")
-			g.P("		arr = append(arr, uint32(c))")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	c, err := ", strconvPackage.Ident("ParseUint"), "(v, 10, 32)")
-			g.P("	if err != nil {")
-			g.P("		cb(ctx, w, r, nil, nil, err)")
-			g.P("		return")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = uint32(c)")
-			g.P("}")
-		}
+		ptr = "Uint32"
 	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([]int64, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		c, err := ", strconvPackage.Ident("ParseInt"), "(v, 10, 64)")
-			g.P("		if err != nil {")
-			g.P("			cb(ctx, w, r, nil, nil, err)")
-			g.P("			return")
-			g.P("		}")
-			g.P("		arr = append(arr, c)")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	c, err := ", strconvPackage.Ident("ParseInt"), "(v, 10, 64)")
-			g.P("	if err != nil {")
-			g.P("		cb(ctx, w, r, nil, nil, err)")
-			g.P("		return")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = c")
-			g.P("}")
-		}
+		ptr = "Int64"
 	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([]uint64, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		c, err := ", strconvPackage.Ident("ParseUint"), "(v, 10, 64)")
-			g.P("		if err != nil {")
-			g.P("			cb(ctx, w, r, nil, nil, err)")
-			g.P("			return")
-			g.P("		}")
-			g.P("		arr = append(arr, c)")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	c, err := ", strconvPackage.Ident("ParseUint"), "(v, 10, 64)")
-			g.P("	if err != nil {")
-			g.P("		cb(ctx, w, r, nil, nil, err)")
-			g.P("		return")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = c")
-			g.P("}")
-		}
+		ptr = "Uint64"
 	case protoreflect.FloatKind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([]float32, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		c, err := ", strconvPackage.Ident("ParseFloat"), "(v, 32)")
-			g.P("		if err != nil {")
-			g.P("			cb(ctx, w, r, nil, nil, err)")
-			g.P("			return")
-			g.P("		}")
-			g.P("		arr = append(arr, float32(c))")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	c, err := ", strconvPackage.Ident("ParseFloat"), "(v, 32)")
-			g.P("	if err != nil {")
-			g.P("		cb(ctx, w, r, nil, nil, err)")
-			g.P("		return")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = float32(c)")
-			g.P("}")
-		}
+		ptr = "Float32"
 	case protoreflect.DoubleKind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([]float64, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		c, err := ", strconvPackage.Ident("ParseFloat"), "(v, 64)")
-			g.P("		if err != nil {")
-			g.P("			cb(ctx, w, r, nil, nil, err)")
-			g.P("			return")
-			g.P("		}")
-			g.P("		arr = append(arr, c)")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	c, err := ", strconvPackage.Ident("ParseFloat"), "(v, 64)")
-			g.P("	if err != nil {")
-			g.P("		cb(ctx, w, r, nil, nil, err)")
-			g.P("		return")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = c")
-			g.P("}")
-		}
+		ptr = "Float64"
 	case protoreflect.StringKind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([]string, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		arr = append(arr, v)")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	arg.", queryParam.GoName, " = v")
-			g.P("}")
-		}
+		ptr = "String"
+	default:
+		// Bytes and message fields are not held by a pointer to carry presence.
+		return value
+	}
+	return fmt.Sprint(g.QualifiedGoIdent(protoPackage.Ident(ptr)), "(", value, ")")
+}
+
+// goType returns the Go type of a single value of field.
+func goType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
 	case protoreflect.BytesKind:
-		if queryParam.Desc.IsList() {
-			g.P("if repeated := r.URL.Query()[\"", queryParam.Name, "\"]; len(repeated) != 0 {")
-			g.P("	arr := make([][]byte, 0, len(repeated))")
-			g.P("	for _, v := range repeated {")
-			g.P("		c, err := ", base64Package.Ident("StdEncoding.DecodeString"), "(v)")
-			g.P("		if err != nil {")
-			g.P("			cb(ctx, w, r, nil, nil, err)")
-			g.P("			return")
-			g.P("		}")
-			g.P("		arr = append(arr, c)")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = arr")
-			g.P("}")
-		} else {
-			g.P("if v := r.URL.Query().Get(\"", queryParam.Name, "\"); v != \"\" {")
-			g.P("	c, err := ", base64Package.Ident("StdEncoding.DecodeString"), "(v)")
-			g.P("	if err != nil {")
-			g.P("		cb(ctx, w, r, nil, nil, err)")
-			g.P("		return")
-			g.P("	}")
-			g.P("	arg.", queryParam.GoName, " = c")
-			g.P("}")
+		return "[]byte"
+	default:
+		return "*" + g.QualifiedGoIdent(genMessageName(field.Message))
+	}
+}
+
+// genConvert emits the conversion of the string expression src into a single
+// value of field and returns the expression of the converted value.
+// fail is called with the name of the error variable when the conversion fails.
+func genConvert(g *protogen.GeneratedFile, field *protogen.Field, src string, fail func(err string)) string {
+	var parse, value string
+
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		parse, value = fmt.Sprint(g.QualifiedGoIdent(strconvPackage.Ident("ParseBool")), "(", src, ")"), "c"
+	case protoreflect.EnumKind:
+		g.P("c, ok := ", field.Enum.GoIdent.GoImportPath.Ident(field.Enum.GoIdent.GoName+"_value"), "[", src, "]")
+		g.P("if !ok {")
+		// Numbers are accepted only when they are values of the enum.
+		g.P("	n, err := ", strconvPackage.Ident("ParseInt"), "(", src, ", 10, 32)")
+		g.P("	if _, known := ", field.Enum.GoIdent.GoImportPath.Ident(field.Enum.GoIdent.GoName+"_name"), "[int32(n)]; err != nil || !known {")
+		g.P("		err = ", fmtPackage.Ident("Errorf"), "(\"invalid value %q for enum ", field.Enum.Desc.FullName(), "\", ", src, ")")
+		fail("err")
+		g.P("	}")
+		g.P("	c = int32(n)")
+		g.P("}")
+		return fmt.Sprint(g.QualifiedGoIdent(field.Enum.GoIdent), "(c)")
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		parse, value = fmt.Sprint(g.QualifiedGoIdent(strconvPackage.Ident("ParseInt")), "(", src, ", 10, 32)"), "int32(c)"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		parse, value = fmt.Sprint(g.QualifiedGoIdent(strconvPackage.Ident("ParseUint")), "(", src, ", 10, 32)"), "uint32(c)"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		parse, value = fmt.Sprint(g.QualifiedGoIdent(strconvPackage.Ident("ParseInt")), "(", src, ", 10, 64)"), "c"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		parse, value = fmt.Sprint(g.QualifiedGoIdent(strconvPackage.Ident("ParseUint")), "(", src, ", 10, 64)"), "c"
+	case protoreflect.FloatKind:
+		parse, value = fmt.Sprint(g.QualifiedGoIdent(strconvPackage.Ident("ParseFloat")), "(", src, ", 32)"), "float32(c)"
+	case protoreflect.DoubleKind:
+		parse, value = fmt.Sprint(g.QualifiedGoIdent(strconvPackage.Ident("ParseFloat")), "(", src, ", 64)"), "c"
+	case protoreflect.StringKind:
+		return src
+	case protoreflect.BytesKind:
+		parse, value = fmt.Sprint(g.QualifiedGoIdent(base64Package.Ident("StdEncoding.DecodeString")), "(", src, ")"), "c"
+	default:
+		if isWrapperMessage(field.Message) {
+			// Wrapper messages are converted from their value field.
+			c := genConvert(g, field.Message.Fields[0], src, fail)
+			return fmt.Sprint("&", g.QualifiedGoIdent(genMessageName(field.Message)), "{Value: ", c, "}")
 		}
+		// Timestamp, Duration and FieldMask are parsed from their JSON string form.
+		g.P("c := &", genMessageName(field.Message), "{}")
+		g.P("if err := ", protojsonPackage.Ident("Unmarshal"), "([]byte(", strconvPackage.Ident("Quote"), "(", src, ")), c); err != nil {")
+		fail("err")
+		g.P("}")
+		return "c"
 	}
+	g.P("c, err := ", parse)
+	g.P("if err != nil {")
+	fail("err")
+	g.P("}")
+	return value
 }
 
 func genMessageName(msg *protogen.Message) protogen.GoIdent {
@@ -738,7 +1234,7 @@ func genMessageName(msg *protogen.Message) protogen.GoIdent {
 	case "google/protobuf/source_context.proto":
 		return sourcecontextpbPackage.Ident(msg.GoIdent.GoName)
 	case "google/protobuf/struct.proto":
-		return statusPackage.Ident(msg.GoIdent.GoName)
+		return structPbPackage.Ident(msg.GoIdent.GoName)
 	case "google/protobuf/timestamp.proto":
 		return timestampPbPackage.Ident(msg.GoIdent.GoName)
 	case "google/protobuf/type.proto":
@@ -0,0 +1,173 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// HTTPStatusError is an error responded with Status instead of 500 Internal Server Error.
+type HTTPStatusError struct {
+	Status int
+	Err    error
+}
+
+func (e *HTTPStatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *HTTPStatusError) Unwrap() error {
+	return e.Err
+}
+
+// Source is the part of an HTTP request a field of the request message is bound from.
+type Source string
+
+const (
+	SourcePath  Source = "path"
+	SourceQuery Source = "query"
+	SourceBody  Source = "body"
+)
+
+// BindingError is an error decoding the request message from an HTTP request, caused
+// by the client. Its status is codes.InvalidArgument with a google.rpc.BadRequest
+// detail, so that it is responded with 400 Bad Request.
+type BindingError struct {
+	Source Source
+	// Field is the path of the field of the request message, or the name of the query
+	// parameter or form field. It is empty when the whole body failed to be decoded.
+	Field string
+	Err   error
+}
+
+func (e *BindingError) Error() string {
+	switch {
+	case e.Field == "":
+		return fmt.Sprintf("invalid request %s: %v", e.Source, e.Err)
+	case e.Source == SourceBody:
+		return fmt.Sprintf("invalid body field %q: %v", e.Field, e.Err)
+	default:
+		return fmt.Sprintf("invalid %s parameter %q: %v", e.Source, e.Field, e.Err)
+	}
+}
+
+func (e *BindingError) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus returns the codes.InvalidArgument status of e.
+func (e *BindingError) GRPCStatus() *status.Status {
+	s := status.New(codes.InvalidArgument, e.Error())
+	field := e.Field
+	if field == "" {
+		field = string(e.Source)
+	}
+	if d, err := s.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: e.Err.Error()},
+		},
+	}); err == nil {
+		s = d
+	}
+	return s
+}
+
+// DefaultCallback is the callback of the generated handlers when none is given.
+// It writes err with WriteError and does nothing on success.
+func DefaultCallback(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+	if err != nil {
+		WriteError(ctx, w, r, err)
+	}
+}
+
+// WriteError writes err as a google.rpc.Status encoded with the Marshaler of the media
+// type negotiated from the Accept header of r, like a response, among the Registry in
+// ctx. It falls back to application/json when none is acceptable.
+//
+// The status of err, found by status.FromError or in the errors wrapped by err, is written
+// with its details, and the HTTP status code is mapped from its code by HTTPStatusFromCode.
+// Other errors are written as codes.Unknown. An HTTPStatusError is written with its Status.
+//
+// Nothing is written when the response is already committed, as reported by Committed.
+func WriteError(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	if Committed(w) {
+		return
+	}
+	s, ok := status.FromError(err)
+	var se interface{ GRPCStatus() *status.Status }
+	if !ok && errors.As(err, &se) {
+		// A wrapped status is found as well.
+		s = se.GRPCStatus()
+	}
+	code := HTTPStatusFromCode(s.Code())
+	var httpErr *HTTPStatusError
+	if errors.As(err, &httpErr) {
+		code = httpErr.Status
+	}
+
+	buf, contentType, err := marshalError(FromContext(ctx), r, s.Proto())
+	if err != nil {
+		w.WriteHeader(code)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(code)
+	_, _ = w.Write(buf)
+}
+
+// marshalError encodes p with the Marshaler of the media type negotiated from the Accept
+// header of r, or with the one of application/json when none is acceptable, and returns
+// it with its media type.
+func marshalError(reg *Registry, r *http.Request, p proto.Message) ([]byte, string, error) {
+	if _, accept, err := reg.ContentTypes(r); err == nil {
+		if m, ok := reg.Get(accept); ok {
+			if buf, err := m.Marshal(p); err == nil {
+				return buf, accept, nil
+			}
+		}
+	}
+	m, ok := reg.Get("application/json")
+	if !ok {
+		m = &JSONMarshaler{}
+	}
+	buf, err := m.Marshal(p)
+	return buf, "application/json", err
+}
+
+// HTTPStatusFromCode returns the HTTP status code of the gRPC status code, following
+// the mapping of google.rpc.Code. Unknown codes are mapped to 500 Internal Server Error.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		// 499 Client Closed Request, not defined by net/http.
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	// codes.Unknown, codes.Internal, codes.DataLoss and the codes not defined by gRPC.
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,210 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/weblfe/protoc-gen-api/pkg/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+)
+
+func TestDefaultCallback(t *testing.T) {
+	detailed, err := status.New(codes.FailedPrecondition, "failed").WithDetails(&sourcecontextpb.SourceContext{FileName: "a.proto"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, spec := range []struct {
+		err     error
+		code    int
+		status  codes.Code
+		message string
+		details int
+	}{
+		{
+			err:    errors.New("failed"),
+			code:   http.StatusInternalServerError,
+			status: codes.Unknown,
+		},
+		{
+			err:    fmt.Errorf("wrapped: %w", &runtime.HTTPStatusError{Status: http.StatusUnsupportedMediaType, Err: errors.New("failed")}),
+			code:   http.StatusUnsupportedMediaType,
+			status: codes.Unknown,
+		},
+		{
+			err:    status.Error(codes.NotFound, "not found"),
+			code:   http.StatusNotFound,
+			status: codes.NotFound,
+		},
+		{
+			err:     fmt.Errorf("wrapped: %w", status.Error(codes.NotFound, "not found")),
+			code:    http.StatusNotFound,
+			status:  codes.NotFound,
+			message: "not found",
+		},
+		{
+			err:     &runtime.BindingError{Source: runtime.SourceQuery, Field: "id", Err: errors.New("failed")},
+			code:    http.StatusBadRequest,
+			status:  codes.InvalidArgument,
+			details: 1,
+		},
+		{
+			err:     detailed.Err(),
+			code:    http.StatusBadRequest,
+			status:  codes.FailedPrecondition,
+			details: 1,
+		},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/", nil)
+		r.Header.Set("Content-Type", "application/json")
+		runtime.DefaultCallback(context.Background(), w, r, nil, nil, spec.err)
+		if w.Code != spec.code {
+			t.Errorf("DefaultCallback(%v) wrote %d; want %d", spec.err, w.Code, spec.code)
+		}
+		got := &spb.Status{}
+		if err := protojson.Unmarshal(w.Body.Bytes(), got); err != nil {
+			t.Errorf("DefaultCallback(%v) wrote %q: %v", spec.err, w.Body.String(), err)
+			continue
+		}
+		want := spec.message
+		if want == "" {
+			want = status.Convert(spec.err).Message()
+		}
+		if got.Message != want {
+			t.Errorf("DefaultCallback(%v) wrote message %q; want %q", spec.err, got.Message, want)
+		}
+		if codes.Code(got.Code) != spec.status || len(got.Details) != spec.details {
+			t.Errorf("DefaultCallback(%v) wrote code %v with %d details; want %v with %d", spec.err, codes.Code(got.Code), len(got.Details), spec.status, spec.details)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	runtime.DefaultCallback(context.Background(), w, httptest.NewRequest(http.MethodGet, "/", nil), nil, nil, nil)
+	if w.Code != http.StatusOK || w.Body.Len() != 0 {
+		t.Errorf("DefaultCallback(nil) wrote %d %q; want nothing", w.Code, w.Body.String())
+	}
+}
+
+func TestWriteErrorContentType(t *testing.T) {
+	for _, spec := range []struct {
+		method      string
+		contentType string
+		accept      string
+		want        string
+	}{
+		{method: http.MethodGet, want: "application/json"},
+		{method: http.MethodGet, accept: "application/protobuf", want: "application/protobuf"},
+		{method: http.MethodGet, accept: "text/html", want: "application/json"},
+		{method: http.MethodPost, contentType: "application/x-protobuf", want: "application/x-protobuf"},
+		{method: http.MethodPost, contentType: "application/protobuf", accept: "application/json", want: "application/json"},
+		{method: http.MethodPost, contentType: "text/plain", accept: "application/*;q=0.5, application/protobuf", want: "application/protobuf"},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(spec.method, "/", nil)
+		if spec.contentType != "" {
+			r.Header.Set("Content-Type", spec.contentType)
+		}
+		if spec.accept != "" {
+			r.Header.Set("Accept", spec.accept)
+		}
+		runtime.WriteError(context.Background(), w, r, status.Error(codes.NotFound, "not found"))
+
+		if got := w.Header().Get("Content-Type"); got != spec.want {
+			t.Errorf("WriteError(%q, %q) wrote Content-Type %q; want %q", spec.contentType, spec.accept, got, spec.want)
+			continue
+		}
+		got := &spb.Status{}
+		var err error
+		if spec.want == "application/json" {
+			err = protojson.Unmarshal(w.Body.Bytes(), got)
+		} else {
+			err = proto.Unmarshal(w.Body.Bytes(), got)
+		}
+		if err != nil || w.Code != http.StatusNotFound || codes.Code(got.Code) != codes.NotFound {
+			t.Errorf("WriteError(%q, %q) wrote %d %q: %v", spec.contentType, spec.accept, w.Code, w.Body.String(), err)
+		}
+	}
+}
+
+func TestHTTPStatusFromCode(t *testing.T) {
+	for code, want := range map[codes.Code]int{
+		codes.OK:                 http.StatusOK,
+		codes.Canceled:           499,
+		codes.Unknown:            http.StatusInternalServerError,
+		codes.InvalidArgument:    http.StatusBadRequest,
+		codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+		codes.NotFound:           http.StatusNotFound,
+		codes.AlreadyExists:      http.StatusConflict,
+		codes.PermissionDenied:   http.StatusForbidden,
+		codes.ResourceExhausted:  http.StatusTooManyRequests,
+		codes.FailedPrecondition: http.StatusBadRequest,
+		codes.Aborted:            http.StatusConflict,
+		codes.OutOfRange:         http.StatusBadRequest,
+		codes.Unimplemented:      http.StatusNotImplemented,
+		codes.Internal:           http.StatusInternalServerError,
+		codes.Unavailable:        http.StatusServiceUnavailable,
+		codes.DataLoss:           http.StatusInternalServerError,
+		codes.Unauthenticated:    http.StatusUnauthorized,
+		codes.Code(100):          http.StatusInternalServerError,
+	} {
+		if got := runtime.HTTPStatusFromCode(code); got != want {
+			t.Errorf("HTTPStatusFromCode(%v) = %d; want %d", code, got, want)
+		}
+	}
+}
+
+func TestBindingError(t *testing.T) {
+	for _, spec := range []struct {
+		err       *runtime.BindingError
+		message   string
+		violation string
+	}{
+		{
+			err:       &runtime.BindingError{Source: runtime.SourcePath, Field: "id", Err: errors.New("failed")},
+			message:   `invalid path parameter "id": failed`,
+			violation: "id",
+		},
+		{
+			err:       &runtime.BindingError{Source: runtime.SourceQuery, Field: "sub.id", Err: errors.New("failed")},
+			message:   `invalid query parameter "sub.id": failed`,
+			violation: "sub.id",
+		},
+		{
+			err:       &runtime.BindingError{Source: runtime.SourceBody, Field: "sub", Err: errors.New("failed")},
+			message:   `invalid body field "sub": failed`,
+			violation: "sub",
+		},
+		{
+			err:       &runtime.BindingError{Source: runtime.SourceBody, Err: errors.New("failed")},
+			message:   `invalid request body: failed`,
+			violation: "body",
+		},
+	} {
+		if got := spec.err.Error(); got != spec.message {
+			t.Errorf("Error() = %q; want %q", got, spec.message)
+		}
+		s := status.Convert(spec.err)
+		if s.Code() != codes.InvalidArgument || s.Message() != spec.message {
+			t.Errorf("status of %v = %v %q; want %v", spec.err, s.Code(), s.Message(), codes.InvalidArgument)
+		}
+		details := s.Details()
+		if len(details) != 1 {
+			t.Errorf("status of %v has details %v; want a BadRequest", spec.err, details)
+			continue
+		}
+		br, ok := details[0].(*errdetails.BadRequest)
+		if !ok || len(br.FieldViolations) != 1 || br.FieldViolations[0].Field != spec.violation || br.FieldViolations[0].Description != "failed" {
+			t.Errorf("status of %v has details %v; want a violation of %q", spec.err, details, spec.violation)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package runtime
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// defaultMaxFormMemory is the default size of a multipart/form-data body kept in
+// memory, the rest of it being stored in temporary files.
+const defaultMaxFormMemory = 32 << 20
+
+// WithMaxFormMemory sets the size in bytes of a multipart/form-data body kept in memory,
+// the rest of it being stored in temporary files. It defaults to 32 MB.
+func WithMaxFormMemory(n int64) Option {
+	return func(r *Registry) {
+		r.maxFormMemory = n
+	}
+}
+
+// isForm reports whether a request body of contentType is decoded as a form.
+func isForm(contentType string) bool {
+	return contentType == "application/x-www-form-urlencoded" || contentType == "multipart/form-data"
+}
+
+// unmarshalForm decodes the form of the request body of r into msg. The form fields are
+// named and converted like the query parameters: a dotted path names a field of a
+// nested message, name[key] an entry of a map, and the values of a repeated field
+// are given by repeating its name. Files uploaded in a multipart/form-data body are
+// set to bytes fields. Fields not found in msg are ignored.
+func (reg *Registry) unmarshalForm(r *http.Request, contentType string, msg proto.Message) error {
+	var (
+		values url.Values
+		files  map[string][]*multipart.FileHeader
+	)
+	if contentType == "multipart/form-data" {
+		if err := r.ParseMultipartForm(reg.maxFormMemory); err != nil {
+			return &BindingError{Source: SourceBody, Err: err}
+		}
+		values, files = r.MultipartForm.Value, r.MultipartForm.File
+	} else {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+		if values, err = url.ParseQuery(string(body)); err != nil {
+			return &BindingError{Source: SourceBody, Err: err}
+		}
+	}
+
+	for _, name := range sortedKeys(values) {
+		if len(values[name]) == 0 {
+			continue
+		}
+		if err := setFormField(msg.ProtoReflect(), name, values[name]); err != nil {
+			return &BindingError{Source: SourceBody, Field: name, Err: err}
+		}
+	}
+	for name, headers := range files {
+		if err := setFormFile(msg.ProtoReflect(), name, headers); err != nil {
+			return &BindingError{Source: SourceBody, Field: name, Err: err}
+		}
+	}
+	return nil
+}
+
+func sortedKeys(values url.Values) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lookupFormField returns the message holding the field named by the form field name
+// and the field, allocating the messages walked into. For name[key], key is the key of
+// the map entry. It returns a nil field descriptor when name names no field bound by a form.
+func lookupFormField(m protoreflect.Message, name string) (protoreflect.Message, protoreflect.FieldDescriptor, string) {
+	var (
+		key    string
+		hasKey bool
+	)
+	if i := strings.IndexByte(name, '['); i > 0 && strings.HasSuffix(name, "]") {
+		name, key, hasKey = name[:i], name[i+1:len(name)-1], true
+	}
+	var (
+		path = strings.Split(name, ".")
+		fds  = make([]protoreflect.FieldDescriptor, 0, len(path))
+		desc = m.Descriptor()
+	)
+	for i, n := range path {
+		fd := desc.Fields().ByName(protoreflect.Name(n))
+		if fd == nil {
+			return nil, nil, ""
+		}
+		fds = append(fds, fd)
+		if i == len(path)-1 {
+			break
+		}
+		// Only singular messages out of a oneof are walked into.
+		if fd.Message() == nil || fd.IsList() || fd.IsMap() || isLeafMessage(fd.Message()) ||
+			(fd.ContainingOneof() != nil && !fd.ContainingOneof().IsSynthetic()) {
+			return nil, nil, ""
+		}
+		desc = fd.Message()
+	}
+	fd := fds[len(fds)-1]
+	if fd.IsMap() != hasKey || (fd.Message() != nil && !fd.IsMap() && !isLeafMessage(fd.Message())) {
+		return nil, nil, ""
+	}
+	for _, parent := range fds[:len(fds)-1] {
+		m = m.Mutable(parent).Message()
+	}
+	return m, fd, key
+}
+
+// isLeafMessage reports whether md is a well-known type given as a single value
+// rather than field by field.
+func isLeafMessage(md protoreflect.MessageDescriptor) bool {
+	switch md.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration", "google.protobuf.FieldMask",
+		"google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue",
+		"google.protobuf.BytesValue":
+		return true
+	}
+	return false
+}
+
+// setFormField sets the values of the form field name to the field of m it names.
+// A singular field takes the first value, and a map entry the last one.
+func setFormField(m protoreflect.Message, name string, values []string) error {
+	m, fd, key := lookupFormField(m, name)
+	switch {
+	case fd == nil:
+		return nil
+	case fd.IsMap():
+		if fd.MapValue().Message() != nil {
+			// Only maps of scalar or enum values can be given in a form.
+			return nil
+		}
+		if key == "" {
+			return fmt.Errorf("empty key of map %s", fd.FullName())
+		}
+		k, err := convertFormValue(fd.MapKey(), key, nil)
+		if err != nil {
+			return err
+		}
+		v, err := convertFormValue(fd.MapValue(), values[len(values)-1], nil)
+		if err != nil {
+			return err
+		}
+		m.Mutable(fd).Map().Set(k.MapKey(), v)
+	case fd.IsList():
+		list := m.NewField(fd).List()
+		for _, s := range values {
+			v, err := convertFormValue(fd, s, list.NewElement)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+		m.Set(fd, protoreflect.ValueOfList(list))
+	default:
+		if values[0] == "" {
+			return nil
+		}
+		v, err := convertFormValue(fd, values[0], func() protoreflect.Value { return m.NewField(fd) })
+		if err != nil {
+			return err
+		}
+		return setOneofField(m, fd, v)
+	}
+	return nil
+}
+
+// setFormFile sets the content of the files of the form field name to the bytes field
+// of m it names. A singular field takes the first file.
+func setFormFile(m protoreflect.Message, name string, headers []*multipart.FileHeader) error {
+	m, fd, _ := lookupFormField(m, name)
+	if fd == nil || fd.IsMap() || len(headers) == 0 {
+		return nil
+	}
+	if fd.Kind() != protoreflect.BytesKind {
+		return fmt.Errorf("a file is given to the %s field %s", fd.Kind(), fd.FullName())
+	}
+	if !fd.IsList() {
+		headers = headers[:1]
+	}
+	list := m.NewField(fd)
+	for _, header := range headers {
+		f, err := header.Open()
+		if err != nil {
+			return err
+		}
+		buf, err := ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		if !fd.IsList() {
+			return setOneofField(m, fd, protoreflect.ValueOfBytes(buf))
+		}
+		list.List().Append(protoreflect.ValueOfBytes(buf))
+	}
+	m.Set(fd, list)
+	return nil
+}
+
+// setOneofField sets v to fd of m, failing when another field of the oneof of fd is set.
+func setOneofField(m protoreflect.Message, fd protoreflect.FieldDescriptor, v protoreflect.Value) error {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		if set := m.WhichOneof(od); set != nil && set != fd {
+			return fmt.Errorf("another field of oneof %s is already set", od.Name())
+		}
+	}
+	m.Set(fd, v)
+	return nil
+}
+
+// convertFormValue converts s to a value of fd. newMessage returns an empty message
+// value of fd, for the message kinds.
+func convertFormValue(fd protoreflect.FieldDescriptor, s string, newMessage func() protoreflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		c, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(c), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+		c, err := strconv.ParseInt(s, 10, 32)
+		if err != nil || fd.Enum().Values().ByNumber(protoreflect.EnumNumber(c)) == nil {
+			return protoreflect.Value{}, fmt.Errorf("invalid value %q for enum %s", s, fd.Enum().FullName())
+		}
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(c)), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		c, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(c)), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		c, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(c)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		c, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(c), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		c, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(c), err
+	case protoreflect.FloatKind:
+		c, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(c)), err
+	case protoreflect.DoubleKind:
+		c, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(c), err
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BytesKind:
+		c, err := base64.StdEncoding.DecodeString(s)
+		return protoreflect.ValueOfBytes(c), err
+	}
+	v := newMessage()
+	if valueField := v.Message().Descriptor().Fields().ByName("value"); valueField != nil {
+		// Wrapper messages are converted from their value field.
+		c, err := convertFormValue(valueField, s, nil)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		v.Message().Set(valueField, c)
+		return v, nil
+	}
+	// Timestamp, Duration and FieldMask are parsed from their JSON string form.
+	err := protojson.Unmarshal([]byte(strconv.Quote(s)), v.Message().Interface())
+	return v, err
+}
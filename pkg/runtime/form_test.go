@@ -0,0 +1,228 @@
+package runtime_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/weblfe/protoc-gen-api/pkg/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const formFile = `
+name: "form.proto"
+package: "form"
+dependency: ["google/protobuf/timestamp.proto", "google/protobuf/wrappers.proto"]
+syntax: "proto3"
+message_type: {
+	name: "Form"
+	field: {name: "name" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "name"}
+	field: {name: "count" number: 2 label: LABEL_OPTIONAL type: TYPE_INT32 json_name: "count"}
+	field: {name: "tags" number: 3 label: LABEL_REPEATED type: TYPE_STRING json_name: "tags"}
+	field: {name: "counts" number: 4 label: LABEL_REPEATED type: TYPE_MESSAGE type_name: ".form.Form.CountsEntry" json_name: "counts"}
+	field: {name: "state" number: 5 label: LABEL_OPTIONAL type: TYPE_ENUM type_name: ".form.State" json_name: "state"}
+	field: {name: "data" number: 6 label: LABEL_OPTIONAL type: TYPE_BYTES json_name: "data"}
+	field: {name: "files" number: 7 label: LABEL_REPEATED type: TYPE_BYTES json_name: "files"}
+	field: {name: "child" number: 8 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".form.Form" json_name: "child"}
+	field: {name: "time" number: 9 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Timestamp" json_name: "time"}
+	field: {name: "limit" number: 10 label: LABEL_OPTIONAL type: TYPE_MESSAGE type_name: ".google.protobuf.Int64Value" json_name: "limit"}
+	field: {name: "email" number: 11 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "email"}
+	field: {name: "phone" number: 12 label: LABEL_OPTIONAL type: TYPE_STRING oneof_index: 0 json_name: "phone"}
+	nested_type: {
+		name: "CountsEntry"
+		field: {name: "key" number: 1 label: LABEL_OPTIONAL type: TYPE_STRING json_name: "key"}
+		field: {name: "value" number: 2 label: LABEL_OPTIONAL type: TYPE_INT64 json_name: "value"}
+		options: {map_entry: true}
+	}
+	oneof_decl: {name: "contact"}
+}
+enum_type: {
+	name: "State"
+	value: {name: "STATE_UNSPECIFIED" number: 0}
+	value: {name: "ACTIVE" number: 1}
+}
+`
+
+// formDesc is the descriptor of form.Form, built once so that the messages made by
+// newForm are comparable with proto.Equal.
+var formDesc protoreflect.MessageDescriptor
+
+func newForm(t *testing.T) *dynamicpb.Message {
+	t.Helper()
+	if formDesc != nil {
+		return dynamicpb.NewMessage(formDesc)
+	}
+	fdp := &descriptorpb.FileDescriptorProto{}
+	if err := prototext.Unmarshal([]byte(formFile), fdp); err != nil {
+		t.Fatal(err)
+	}
+	fd, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	formDesc = fd.Messages().ByName("Form")
+	return dynamicpb.NewMessage(formDesc)
+}
+
+func formText(t *testing.T, msg proto.Message) string {
+	t.Helper()
+	buf, err := prototext.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Join(strings.Fields(string(buf)), " ")
+}
+
+func TestUnmarshalRequestWithURLEncodedForm(t *testing.T) {
+	for _, spec := range []struct {
+		body  string
+		field string
+		want  string
+	}{
+		{
+			body: "name=gopher&count=3&unknown=1&tags=a&tags=b&counts[x]=1&counts[y]=2&counts[y]=5&state=ACTIVE",
+			want: `name: "gopher" count: 3 tags: "a" tags: "b" counts: { key: "x" value: 1 } counts: { key: "y" value: 5 } state: ACTIVE`,
+		},
+		{
+			body: "child.child.name=deep&child.state=1&data=aGVsbG8%3D&time=2020-01-02T03:04:05Z&limit=10&phone=123",
+			want: `data: "hello" child: { state: ACTIVE child: { name: "deep" } } time: { seconds: 1577934245 } limit: { value: 10 } phone: "123"`,
+		},
+		{
+			body:  "name=gopher&count=3",
+			field: "child",
+			want:  `child: { name: "gopher" count: 3 }`,
+		},
+		{
+			body:  "name=gopher&tags=a",
+			field: "tags",
+			want:  `tags: "a"`,
+		},
+		{
+			body: "name=&count=&child.unknown=1",
+			want: ``,
+		},
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(spec.body))
+		msg := newForm(t)
+		if err := runtime.NewRegistry().UnmarshalRequest(r, "application/x-www-form-urlencoded", msg, spec.field); err != nil {
+			t.Errorf("UnmarshalRequest(%q, %q) = %v", spec.body, spec.field, err)
+			continue
+		}
+		want := newForm(t)
+		if err := prototext.Unmarshal([]byte(spec.want), want); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(msg, want) {
+			t.Errorf("UnmarshalRequest(%q, %q) = {%s}; want {%s}", spec.body, spec.field, formText(t, msg), formText(t, want))
+		}
+	}
+}
+
+func TestUnmarshalRequestWithInvalidForm(t *testing.T) {
+	for _, body := range []string{
+		"count=three",
+		"state=UNKNOWN",
+		"state=999",
+		"state=-5",
+		"counts[x]=y",
+		"counts[]=1",
+		"email=a&phone=b",
+		"time=yesterday",
+		"name=%zz",
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		err := runtime.NewRegistry().UnmarshalRequest(r, "application/x-www-form-urlencoded", newForm(t), "")
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("UnmarshalRequest(%q) = %v; want %v", body, err, codes.InvalidArgument)
+		}
+	}
+
+	// The form fields of a body field are named in its message.
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("child.count=x"))
+	err := runtime.NewRegistry().UnmarshalRequest(r, "application/x-www-form-urlencoded", newForm(t), "child")
+	var bindErr *runtime.BindingError
+	if !errors.As(err, &bindErr) || bindErr.Field != "child.child.count" {
+		t.Errorf("UnmarshalRequest(child.count=x) = %v; want a BindingError of %q", err, "child.child.count")
+	}
+}
+
+func TestUnmarshalRequestWithMultipartForm(t *testing.T) {
+	var (
+		body bytes.Buffer
+		mw   = multipart.NewWriter(&body)
+	)
+	for _, part := range []struct {
+		name, file, content string
+	}{
+		{name: "name", content: "gopher"},
+		{name: "child.name", content: "child"},
+		{name: "data", file: "a.txt", content: "first"},
+		{name: "data", file: "b.txt", content: "second"},
+		{name: "files", file: "a.txt", content: "first"},
+		{name: "files", file: "b.txt", content: "second"},
+		{name: "unknown", file: "c.txt", content: "ignored"},
+	} {
+		var err error
+		if part.file == "" {
+			err = mw.WriteField(part.name, part.content)
+		} else {
+			var w io.Writer
+			if w, err = mw.CreateFormFile(part.name, part.file); err == nil {
+				_, err = w.Write([]byte(part.content))
+			}
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body.Bytes()))
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	msg := newForm(t)
+	reg := runtime.NewRegistry(runtime.WithMaxFormMemory(1))
+	if err := reg.UnmarshalRequest(r, "multipart/form-data", msg, ""); err != nil {
+		t.Fatal(err)
+	}
+	want := newForm(t)
+	if err := prototext.Unmarshal([]byte(`name: "gopher" data: "first" files: "first" files: "second" child: { name: "child" }`), want); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(msg, want) {
+		t.Errorf("UnmarshalRequest() = {%s}; want {%s}", formText(t, msg), formText(t, want))
+	}
+
+	// A file given to a field other than bytes is invalid.
+	body.Reset()
+	mw = multipart.NewWriter(&body)
+	if w, err := mw.CreateFormFile("name", "a.txt"); err != nil {
+		t.Fatal(err)
+	} else if _, err := w.Write([]byte("gopher")); err != nil {
+		t.Fatal(err)
+	}
+	if err := mw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	r = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body.Bytes()))
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	if err := reg.UnmarshalRequest(r, "multipart/form-data", newForm(t), ""); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("UnmarshalRequest(file to string field) = %v; want %v", err, codes.InvalidArgument)
+	}
+}
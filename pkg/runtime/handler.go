@@ -0,0 +1,191 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// UnmarshalRequest reads the body of r and decodes it into msg with the Marshaler
+// of contentType, or as a form for application/x-www-form-urlencoded and
+// multipart/form-data. When field is not empty only the field of msg of that name is
+// populated from the body, as selected by the HttpRule body field.
+func (reg *Registry) UnmarshalRequest(r *http.Request, contentType string, msg proto.Message, field string) error {
+	var (
+		unmarshal func(msg proto.Message) error
+		body      []byte
+		wrapJSON  bool
+	)
+	if isForm(contentType) {
+		unmarshal = func(msg proto.Message) error {
+			return reg.unmarshalForm(r, contentType, msg)
+		}
+	} else {
+		var err error
+		if body, err = ioutil.ReadAll(r.Body); err != nil {
+			return &BindingError{Source: SourceBody, Field: field, Err: err}
+		}
+		m, ok := reg.Get(contentType)
+		if !ok {
+			return &HTTPStatusError{
+				Status: http.StatusUnsupportedMediaType,
+				Err:    fmt.Errorf("Unsupported Content-Type: %s", contentType),
+			}
+		}
+		unmarshal = func(msg proto.Message) error {
+			return m.Unmarshal(body, msg)
+		}
+		wrapJSON = isJSON(m)
+	}
+	if field == "" {
+		return bodyError(field, unmarshal(msg))
+	}
+
+	fd := msg.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil {
+		return fmt.Errorf("%s has no field %q", msg.ProtoReflect().Descriptor().FullName(), field)
+	}
+	if fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+		err := unmarshal(msg.ProtoReflect().Mutable(fd).Message().Interface())
+		var bindErr *BindingError
+		if errors.As(err, &bindErr) {
+			// The form fields are named in the message of the body field.
+			if bindErr.Field == "" {
+				bindErr.Field = field
+			} else {
+				bindErr.Field = field + "." + bindErr.Field
+			}
+		}
+		return bodyError(field, err)
+	}
+	// Other fields are decoded through a copy of msg, so a JSON body is wrapped
+	// as the value of the field.
+	tmp := msg.ProtoReflect().New()
+	if wrapJSON {
+		body = append(append([]byte(fmt.Sprintf("{%q:", fd.Name())), body...), '}')
+	}
+	if err := unmarshal(tmp.Interface()); err != nil {
+		return bodyError(field, err)
+	}
+	if tmp.Has(fd) {
+		msg.ProtoReflect().Set(fd, tmp.Get(fd))
+	}
+	return nil
+}
+
+// bodyError returns err as a BindingError of the body field, unless err is nil or
+// already a BindingError.
+func bodyError(field string, err error) error {
+	var bindErr *BindingError
+	if err == nil || errors.As(err, &bindErr) {
+		return err
+	}
+	return &BindingError{Source: SourceBody, Field: field, Err: err}
+}
+
+// WriteResponse encodes msg with the Marshaler of accept and writes it to w, with the
+// Content-Type header set to accept. Nothing is written when msg fails to be encoded.
+// When field is not empty only the field of msg of that name is written, as selected
+// by the HttpRule response_body field.
+func (reg *Registry) WriteResponse(w http.ResponseWriter, accept string, msg proto.Message, field string) error {
+	m, ok := reg.Get(accept)
+	if !ok {
+		return &HTTPStatusError{
+			Status: http.StatusNotAcceptable,
+			Err:    fmt.Errorf("Not Acceptable: %s", accept),
+		}
+	}
+	buf, err := marshalResponse(m, msg, field)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", accept)
+	_, err = w.Write(buf)
+	return err
+}
+
+func marshalResponse(m Marshaler, msg proto.Message, field string) ([]byte, error) {
+	if field == "" {
+		return m.Marshal(msg)
+	}
+
+	fd := msg.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil {
+		return nil, fmt.Errorf("%s has no field %q", msg.ProtoReflect().Descriptor().FullName(), field)
+	}
+	if fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+		if isJSON(m) && !msg.ProtoReflect().Has(fd) {
+			// An unset message field is written as null rather than as an empty message.
+			return []byte("null"), nil
+		}
+		return m.Marshal(msg.ProtoReflect().Get(fd).Message().Interface())
+	}
+	// Other fields are written through a copy of msg holding only that field.
+	res := msg.ProtoReflect().New()
+	if msg.ProtoReflect().Has(fd) {
+		res.Set(fd, msg.ProtoReflect().Get(fd))
+	}
+	buf, err := m.Marshal(res.Interface())
+	if err != nil || !isJSON(m) {
+		return buf, err
+	}
+	if v, ok, err := jsonField(buf, fd); err != nil || ok {
+		return v, err
+	}
+	// A field left out as unpopulated is written as its zero value, encoded with the
+	// options of m.
+	if jm, ok := m.(*JSONMarshaler); ok {
+		opts := jm.MarshalOptions
+		opts.EmitUnpopulated = true
+		if buf, err = opts.Marshal(res.Interface()); err != nil {
+			return nil, err
+		}
+		if v, ok, err := jsonField(buf, fd); err != nil || ok {
+			return v, err
+		}
+	}
+	switch {
+	case fd.IsList():
+		return []byte("[]"), nil
+	case fd.IsMap():
+		return []byte("{}"), nil
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// jsonField returns the value of fd in the JSON object buf, named either by its JSON
+// name or by its proto name.
+func jsonField(buf []byte, fd protoreflect.FieldDescriptor) (json.RawMessage, bool, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		return nil, false, err
+	}
+	if v, ok := fields[fd.JSONName()]; ok {
+		return v, true, nil
+	}
+	v, ok := fields[string(fd.Name())]
+	return v, ok, nil
+}
+
+// ChainUnaryServer returns an interceptor calling interceptors in order,
+// the first one being the outermost.
+func ChainUnaryServer(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			inter, next := interceptors[i], chained
+			chained = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return inter(ctx, req, info, next)
+			}
+		}
+		return chained(ctx, req)
+	}
+}
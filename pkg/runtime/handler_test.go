@@ -0,0 +1,264 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/weblfe/protoc-gen-api/pkg/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/sourcecontextpb"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+func TestUnmarshalRequest(t *testing.T) {
+	for _, spec := range []struct {
+		contentType string
+		body        string
+		field       string
+		want        *apipb.Api
+	}{
+		{
+			contentType: "application/json",
+			body:        `{"name":"a","version":"v1"}`,
+			want:        &apipb.Api{Name: "a", Version: "v1"},
+		},
+		{
+			contentType: "application/json",
+			body:        `{"fileName":"a.proto"}`,
+			field:       "source_context",
+			want:        &apipb.Api{Name: "kept", SourceContext: &sourcecontextpb.SourceContext{FileName: "a.proto"}},
+		},
+		{
+			contentType: "application/json",
+			body:        `"v2"`,
+			field:       "version",
+			want:        &apipb.Api{Name: "kept", Version: "v2"},
+		},
+		{
+			contentType: "application/json",
+			body:        `[{"name":"m"}]`,
+			field:       "methods",
+			want:        &apipb.Api{Name: "kept", Methods: []*apipb.Method{{Name: "m"}}},
+		},
+		{
+			contentType: "application/protobuf",
+			body:        mustMarshal(t, &apipb.Api{Version: "v3"}),
+			field:       "version",
+			want:        &apipb.Api{Name: "kept", Version: "v3"},
+		},
+	} {
+		msg := &apipb.Api{}
+		if spec.field != "" {
+			msg.Name = "kept"
+		}
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(spec.body))
+		if err := runtime.DefaultRegistry.UnmarshalRequest(r, spec.contentType, msg, spec.field); err != nil {
+			t.Errorf("UnmarshalRequest(%q, %q) failed with %v; want success", spec.body, spec.field, err)
+			continue
+		}
+		if !proto.Equal(msg, spec.want) {
+			t.Errorf("UnmarshalRequest(%q, %q) = %v; want %v", spec.body, spec.field, msg, spec.want)
+		}
+	}
+}
+
+func TestUnmarshalRequestWithUnsupportedMediaType(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("a=b"))
+	err := runtime.DefaultRegistry.UnmarshalRequest(r, "text/plain", &apipb.Api{}, "")
+	var httpErr *runtime.HTTPStatusError
+	if !errors.As(err, &httpErr) || httpErr.Status != http.StatusUnsupportedMediaType {
+		t.Errorf("UnmarshalRequest(text/plain) = %v; want %d", err, http.StatusUnsupportedMediaType)
+	}
+}
+
+func TestUnmarshalRequestWithInvalidBody(t *testing.T) {
+	for _, spec := range []struct {
+		contentType string
+		body        string
+		field       string
+		wantField   string
+	}{
+		{contentType: "application/json", body: `{"name":`},
+		{contentType: "application/json", body: `{"fileName":1}`, field: "source_context", wantField: "source_context"},
+		{contentType: "application/protobuf", body: "\xff", field: "methods", wantField: "methods"},
+		{contentType: "application/x-www-form-urlencoded", body: "syntax=X", wantField: "syntax"},
+		{contentType: "application/x-www-form-urlencoded", body: "file_name=%zz", field: "source_context", wantField: "source_context"},
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(spec.body))
+		err := runtime.DefaultRegistry.UnmarshalRequest(r, spec.contentType, &apipb.Api{}, spec.field)
+		var bindErr *runtime.BindingError
+		if !errors.As(err, &bindErr) || bindErr.Source != runtime.SourceBody || bindErr.Field != spec.wantField {
+			t.Errorf("UnmarshalRequest(%q, %q, %q) = %#v; want a BindingError of the body field %q", spec.contentType, spec.body, spec.field, err, spec.wantField)
+		}
+	}
+}
+
+func TestWriteResponse(t *testing.T) {
+	msg := &apipb.Api{
+		Name:          "a",
+		Methods:       []*apipb.Method{{Name: "m"}},
+		SourceContext: &sourcecontextpb.SourceContext{FileName: "a.proto"},
+	}
+	for _, spec := range []struct {
+		accept string
+		field  string
+		want   string
+	}{
+		{
+			accept: "application/json",
+			field:  "source_context",
+			want:   `{"fileName":"a.proto"}`,
+		},
+		{
+			accept: "application/json",
+			field:  "name",
+			want:   `"a"`,
+		},
+		{
+			accept: "application/json",
+			field:  "version",
+			want:   `""`,
+		},
+		{
+			accept: "application/json",
+			field:  "syntax",
+			want:   `"SYNTAX_PROTO2"`,
+		},
+		{
+			accept: "application/json",
+			field:  "mixins",
+			want:   `[]`,
+		},
+		{
+			accept: "application/protobuf",
+			field:  "name",
+			want:   mustMarshal(t, &apipb.Api{Name: "a"}),
+		},
+	} {
+		w := httptest.NewRecorder()
+		if err := runtime.DefaultRegistry.WriteResponse(w, spec.accept, msg, spec.field); err != nil {
+			t.Errorf("WriteResponse(%q, %q) failed with %v; want success", spec.accept, spec.field, err)
+			continue
+		}
+		got := w.Body.String()
+		if spec.accept == "application/json" {
+			// protojson randomly adds spaces to its output.
+			got = strings.Join(strings.Fields(got), "")
+		}
+		if got != spec.want {
+			t.Errorf("WriteResponse(%q, %q) = %q; want %q", spec.accept, spec.field, got, spec.want)
+		}
+	}
+}
+
+func TestWriteResponseWithUnsetMessage(t *testing.T) {
+	msg := newForm(t)
+	if err := prototext.Unmarshal([]byte(`name: "a"`), msg); err != nil {
+		t.Fatal(err)
+	}
+	for _, spec := range []struct {
+		accept string
+		field  string
+		want   string
+	}{
+		{accept: "application/json", field: "time", want: `null`},
+		{accept: "application/json", field: "limit", want: `null`},
+		{accept: "application/json", field: "child", want: `null`},
+		{accept: "application/protobuf", field: "child", want: ``},
+	} {
+		w := httptest.NewRecorder()
+		if err := runtime.DefaultRegistry.WriteResponse(w, spec.accept, msg, spec.field); err != nil {
+			t.Errorf("WriteResponse(%q, %q) failed with %v; want success", spec.accept, spec.field, err)
+			continue
+		}
+		if got := w.Body.String(); got != spec.want {
+			t.Errorf("WriteResponse(%q, %q) = %q; want %q", spec.accept, spec.field, got, spec.want)
+		}
+	}
+
+	// Set message fields are written as usual, even when empty.
+	if err := prototext.Unmarshal([]byte(`time: {} limit: {} child: {}`), msg); err != nil {
+		t.Fatal(err)
+	}
+	for field, want := range map[string]string{
+		"time":  `"1970-01-01T00:00:00Z"`,
+		"limit": `"0"`,
+		"child": `{}`,
+	} {
+		w := httptest.NewRecorder()
+		if err := runtime.DefaultRegistry.WriteResponse(w, "application/json", msg, field); err != nil {
+			t.Errorf("WriteResponse(%q) failed with %v; want success", field, err)
+			continue
+		}
+		if got := strings.Join(strings.Fields(w.Body.String()), ""); got != want {
+			t.Errorf("WriteResponse(%q) = %q; want %q", field, got, want)
+		}
+	}
+}
+
+func TestWriteResponseWithMarshalOptions(t *testing.T) {
+	reg := runtime.NewRegistry(runtime.WithMarshalOptions(protojson.MarshalOptions{
+		UseEnumNumbers: true,
+		UseProtoNames:  true,
+	}))
+	msg := &apipb.Api{SourceContext: &sourcecontextpb.SourceContext{FileName: "a.proto"}}
+	for _, spec := range []struct {
+		field string
+		want  string
+	}{
+		{field: "syntax", want: `0`},
+		{field: "version", want: `""`},
+		{field: "source_context", want: `{"file_name":"a.proto"}`},
+	} {
+		w := httptest.NewRecorder()
+		if err := reg.WriteResponse(w, "application/json", msg, spec.field); err != nil {
+			t.Errorf("WriteResponse(%q) failed with %v; want success", spec.field, err)
+			continue
+		}
+		if got := strings.Join(strings.Fields(w.Body.String()), ""); got != spec.want {
+			t.Errorf("WriteResponse(%q) = %q; want %q", spec.field, got, spec.want)
+		}
+	}
+}
+
+func TestChainUnaryServer(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "handler")
+		return req, nil
+	}
+	chained := runtime.ChainUnaryServer(interceptor("first"), interceptor("second"))
+	ret, err := chained(context.Background(), &typepb.Type{Name: "t"}, &grpc.UnaryServerInfo{}, handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := calls, []string{"first", "second", "handler"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("calls = %q; want %q", got, want)
+	}
+	if !proto.Equal(ret.(proto.Message), &typepb.Type{Name: "t"}) {
+		t.Errorf("ret = %v", ret)
+	}
+}
+
+func mustMarshal(t *testing.T, m proto.Message) string {
+	buf, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(buf)
+}
@@ -0,0 +1,148 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Marshaler encodes and decodes messages in a media type.
+type Marshaler interface {
+	// ContentType returns the value of the Content-Type header of encoded messages.
+	ContentType() string
+	Marshal(proto.Message) ([]byte, error)
+	Unmarshal([]byte, proto.Message) error
+}
+
+// ProtoMarshaler encodes messages in the protobuf wire format.
+type ProtoMarshaler struct{}
+
+func (ProtoMarshaler) ContentType() string {
+	return "application/protobuf"
+}
+
+func (ProtoMarshaler) Marshal(m proto.Message) ([]byte, error) {
+	return proto.Marshal(m)
+}
+
+func (ProtoMarshaler) Unmarshal(b []byte, m proto.Message) error {
+	return proto.Unmarshal(b, m)
+}
+
+// JSONMarshaler encodes messages in the JSON mapping of protobuf.
+type JSONMarshaler struct {
+	MarshalOptions   protojson.MarshalOptions
+	UnmarshalOptions protojson.UnmarshalOptions
+}
+
+func (*JSONMarshaler) ContentType() string {
+	return "application/json"
+}
+
+func (j *JSONMarshaler) Marshal(m proto.Message) ([]byte, error) {
+	return j.MarshalOptions.Marshal(m)
+}
+
+func (j *JSONMarshaler) Unmarshal(b []byte, m proto.Message) error {
+	return j.UnmarshalOptions.Unmarshal(b, m)
+}
+
+// isJSON reports whether m encodes messages as JSON, where a field out of a
+// message is a bare JSON value.
+func isJSON(m Marshaler) bool {
+	return strings.HasSuffix(m.ContentType(), "json")
+}
+
+// Registry holds the Marshaler of each media type.
+type Registry struct {
+	locker     sync.RWMutex
+	marshalers map[string]Marshaler
+	// maxFormMemory is the size of a multipart/form-data body kept in memory.
+	maxFormMemory int64
+	// headerMatcher names the response headers of the metadata set by the services.
+	headerMatcher HeaderMatcher
+}
+
+// DefaultRegistry is the Registry used when none is found in the context.
+var DefaultRegistry = NewRegistry()
+
+// Option configures a Registry made by NewRegistry.
+type Option func(*Registry)
+
+// WithMarshalOptions sets the options encoding messages in application/json.
+func WithMarshalOptions(o protojson.MarshalOptions) Option {
+	return func(r *Registry) {
+		r.json().MarshalOptions = o
+	}
+}
+
+// WithUnmarshalOptions sets the options decoding messages in application/json.
+func WithUnmarshalOptions(o protojson.UnmarshalOptions) Option {
+	return func(r *Registry) {
+		r.json().UnmarshalOptions = o
+	}
+}
+
+// NewRegistry returns a Registry of application/json, application/protobuf
+// and application/x-protobuf.
+func NewRegistry(options ...Option) *Registry {
+	r := &Registry{
+		marshalers:    make(map[string]Marshaler),
+		maxFormMemory: defaultMaxFormMemory,
+		headerMatcher: DefaultHeaderMatcher,
+	}
+	r.Add("application/json", &JSONMarshaler{})
+	r.Add("application/protobuf", ProtoMarshaler{})
+	r.Add("application/x-protobuf", ProtoMarshaler{})
+	for _, o := range options {
+		o(r)
+	}
+	return r
+}
+
+// json returns the JSONMarshaler of application/json, registering a new one when
+// application/json has another Marshaler.
+func (r *Registry) json() *JSONMarshaler {
+	if m, ok := r.Get("application/json"); ok {
+		if j, ok := m.(*JSONMarshaler); ok {
+			return j
+		}
+	}
+	j := &JSONMarshaler{}
+	r.Add("application/json", j)
+	return j
+}
+
+// Add registers m for mediaType, replacing the Marshaler registered before.
+func (r *Registry) Add(mediaType string, m Marshaler) {
+	r.locker.Lock()
+	defer r.locker.Unlock()
+	r.marshalers[mediaType] = m
+}
+
+// Get returns the Marshaler registered for mediaType.
+func (r *Registry) Get(mediaType string) (Marshaler, bool) {
+	r.locker.RLock()
+	defer r.locker.RUnlock()
+	m, ok := r.marshalers[mediaType]
+	return m, ok
+}
+
+type registryKey struct{}
+
+// NewContext returns a copy of ctx holding reg, the Registry of the converter
+// serving the request.
+func NewContext(ctx context.Context, reg *Registry) context.Context {
+	return context.WithValue(ctx, registryKey{}, reg)
+}
+
+// FromContext returns the Registry held by ctx, or DefaultRegistry.
+func FromContext(ctx context.Context) *Registry {
+	if reg, ok := ctx.Value(registryKey{}).(*Registry); ok && reg != nil {
+		return reg
+	}
+	return DefaultRegistry
+}
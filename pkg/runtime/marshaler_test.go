@@ -0,0 +1,68 @@
+package runtime_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/weblfe/protoc-gen-api/pkg/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestRegistry(t *testing.T) {
+	reg := runtime.NewRegistry()
+	for _, mediaType := range []string{"application/json", "application/protobuf", "application/x-protobuf"} {
+		if _, ok := reg.Get(mediaType); !ok {
+			t.Errorf("NewRegistry().Get(%q) is not found", mediaType)
+		}
+	}
+	if _, ok := reg.Get("text/plain"); ok {
+		t.Errorf("NewRegistry().Get(%q) is found", "text/plain")
+	}
+
+	m := &runtime.JSONMarshaler{}
+	reg.Add("text/plain", m)
+	if got, ok := reg.Get("text/plain"); !ok || got != m {
+		t.Errorf("Get(%q) = %v, %v; want the added Marshaler", "text/plain", got, ok)
+	}
+	if _, ok := runtime.DefaultRegistry.Get("text/plain"); ok {
+		t.Errorf("DefaultRegistry.Get(%q) is found after adding it to another Registry", "text/plain")
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if got := runtime.FromContext(context.Background()); got != runtime.DefaultRegistry {
+		t.Errorf("FromContext(Background) = %p; want DefaultRegistry", got)
+	}
+	reg := runtime.NewRegistry()
+	if got := runtime.FromContext(runtime.NewContext(context.Background(), reg)); got != reg {
+		t.Errorf("FromContext(NewContext(reg)) = %p; want %p", got, reg)
+	}
+}
+
+func TestNewRegistry_Options(t *testing.T) {
+	reg := runtime.NewRegistry(
+		runtime.WithMarshalOptions(protojson.MarshalOptions{UseProtoNames: true}),
+		runtime.WithUnmarshalOptions(protojson.UnmarshalOptions{DiscardUnknown: true}),
+	)
+	m, _ := reg.Get("application/json")
+	j, ok := m.(*runtime.JSONMarshaler)
+	if !ok {
+		t.Fatalf("Get(%q) = %T; want *JSONMarshaler", "application/json", m)
+	}
+	if !j.MarshalOptions.UseProtoNames || !j.UnmarshalOptions.DiscardUnknown {
+		t.Errorf("JSONMarshaler = %+v; want the options given to NewRegistry", j)
+	}
+
+	// The options replace a Marshaler of application/json not being a JSONMarshaler.
+	reg = runtime.NewRegistry(
+		func(r *runtime.Registry) { r.Add("application/json", runtime.ProtoMarshaler{}) },
+		runtime.WithMarshalOptions(protojson.MarshalOptions{UseProtoNames: true}),
+	)
+	if m, _ := reg.Get("application/json"); m.ContentType() != "application/json" {
+		t.Errorf("Get(%q).ContentType() = %q", "application/json", m.ContentType())
+	}
+
+	if m, _ := runtime.DefaultRegistry.Get("application/json"); m.(*runtime.JSONMarshaler).MarshalOptions.UseProtoNames {
+		t.Errorf("DefaultRegistry is changed by the options of another Registry")
+	}
+}
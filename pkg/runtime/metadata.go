@@ -0,0 +1,116 @@
+package runtime
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// HeaderMatcher returns the name of the HTTP header, or trailer, of the metadata key
+// set by a service, and whether the metadata is written at all.
+type HeaderMatcher func(key string) (string, bool)
+
+// DefaultHeaderMatcher writes every metadata key prefixed by "Grpc-Metadata-".
+func DefaultHeaderMatcher(key string) (string, bool) {
+	return "Grpc-Metadata-" + key, true
+}
+
+// WithHeaderMatcher sets the HeaderMatcher naming the response headers and trailers of
+// the metadata set by the services. It defaults to DefaultHeaderMatcher.
+func WithHeaderMatcher(m HeaderMatcher) Option {
+	return func(r *Registry) {
+		r.headerMatcher = m
+	}
+}
+
+// ServerTransportStream is the grpc.ServerTransportStream of a call of a service by a
+// generated handler. It collects the metadata set with grpc.SetHeader, grpc.SendHeader
+// and grpc.SetTrailer, to be written as the header and trailers of the response.
+type ServerTransportStream struct {
+	method string
+
+	locker  sync.Mutex
+	header  metadata.MD
+	trailer metadata.MD
+}
+
+// NewServerTransportStream returns a copy of ctx holding a new ServerTransportStream of
+// the full method name method, and the ServerTransportStream.
+func NewServerTransportStream(ctx context.Context, method string) (context.Context, *ServerTransportStream) {
+	stream := &ServerTransportStream{method: method}
+	return grpc.NewContextWithServerTransportStream(ctx, stream), stream
+}
+
+// Method returns the full method name of the call.
+func (s *ServerTransportStream) Method() string {
+	return s.method
+}
+
+// SetHeader merges md into the header metadata.
+func (s *ServerTransportStream) SetHeader(md metadata.MD) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// SendHeader merges md into the header metadata. The header is sent with the response,
+// after the service returns.
+func (s *ServerTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+// SetTrailer merges md into the trailer metadata.
+func (s *ServerTransportStream) SetTrailer(md metadata.MD) error {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// Header returns a copy of the header metadata.
+func (s *ServerTransportStream) Header() metadata.MD {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	return s.header.Copy()
+}
+
+// Trailer returns a copy of the trailer metadata.
+func (s *ServerTransportStream) Trailer() metadata.MD {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+	return s.trailer.Copy()
+}
+
+// WriteMetadata sets the header metadata of stream to the header of w and its trailer
+// metadata to the trailers of w, named by the HeaderMatcher of reg. The values of the
+// binary metadata, whose key ends with "-bin", are encoded in base64. It must be called
+// before the response is committed.
+func (reg *Registry) WriteMetadata(w http.ResponseWriter, stream *ServerTransportStream) {
+	matcher := reg.headerMatcher
+	if matcher == nil {
+		matcher = DefaultHeaderMatcher
+	}
+	set := func(md metadata.MD, prefix string) {
+		for key, values := range md {
+			name, ok := matcher(key)
+			if !ok {
+				continue
+			}
+			for _, v := range values {
+				if strings.HasSuffix(key, "-bin") {
+					v = base64.StdEncoding.EncodeToString([]byte(v))
+				}
+				w.Header().Add(prefix+name, v)
+			}
+		}
+	}
+	set(stream.Header(), "")
+	// Trailers set before the response is committed are declared by their prefix.
+	set(stream.Trailer(), http.TrailerPrefix)
+}
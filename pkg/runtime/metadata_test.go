@@ -0,0 +1,65 @@
+package runtime_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/weblfe/protoc-gen-api/pkg/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWriteMetadata(t *testing.T) {
+	ctx, stream := runtime.NewServerTransportStream(context.Background(), "/pkg.Service/Method")
+	if method, ok := grpc.Method(ctx); !ok || method != "/pkg.Service/Method" {
+		t.Errorf("grpc.Method() = %q, %v; want %q", method, ok, "/pkg.Service/Method")
+	}
+	for _, err := range []error{
+		grpc.SetHeader(ctx, metadata.Pairs("x-id", "1")),
+		grpc.SendHeader(ctx, metadata.Pairs("x-id", "2", "x-data-bin", "\x00\x01")),
+		grpc.SetTrailer(ctx, metadata.Pairs("x-count", "3", "x-private", "secret")),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	reg := runtime.NewRegistry()
+	reg.WriteMetadata(rec, stream)
+	rec.WriteHeader(http.StatusOK)
+	res := rec.Result()
+	if got := strings.Join(res.Header.Values("Grpc-Metadata-X-Id"), ","); got != "1,2" {
+		t.Errorf("Grpc-Metadata-X-Id = %q; want %q", got, "1,2")
+	}
+	if got := res.Header.Get("Grpc-Metadata-X-Data-Bin"); got != "AAE=" {
+		t.Errorf("Grpc-Metadata-X-Data-Bin = %q; want %q", got, "AAE=")
+	}
+	if got := res.Trailer.Get("Grpc-Metadata-X-Count"); got != "3" {
+		t.Errorf("trailer Grpc-Metadata-X-Count = %q; want %q", got, "3")
+	}
+
+	// The HeaderMatcher names the headers and drops the metadata it rejects.
+	rec = httptest.NewRecorder()
+	reg = runtime.NewRegistry(runtime.WithHeaderMatcher(func(key string) (string, bool) {
+		if key == "x-private" {
+			return "", false
+		}
+		return key, true
+	}))
+	reg.WriteMetadata(rec, stream)
+	rec.WriteHeader(http.StatusOK)
+	res = rec.Result()
+	if got := res.Header.Get("X-Id"); got != "1" {
+		t.Errorf("X-Id = %q; want %q", got, "1")
+	}
+	if got := res.Trailer.Get("X-Count"); got != "3" {
+		t.Errorf("trailer X-Count = %q; want %q", got, "3")
+	}
+	if _, ok := res.Trailer["X-Private"]; ok {
+		t.Errorf("trailer X-Private is written")
+	}
+}
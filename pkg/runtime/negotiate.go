@@ -0,0 +1,133 @@
+package runtime
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentTypes returns the media type of the request body of r and the media type of
+// the response, negotiated from the Accept header of r among the media types of reg
+// as described by RFC 7231 section 5.3.2. Media types are preferred by their quality
+// value, then by their order in the Accept header. Those matched by the same wildcard
+// are preferred in the order of the request media type, application/json, then the
+// others in lexical order. A missing Accept accepts any media type.
+//
+// It returns an HTTPStatusError of 406 Not Acceptable when none of the media types of
+// reg is acceptable.
+func (reg *Registry) ContentTypes(r *http.Request) (contentType, accept string, err error) {
+	contentType = requestContentType(r)
+
+	header := r.Header.Get("Accept")
+	ranges := parseAccept(header)
+	if strings.TrimSpace(header) == "" {
+		ranges = []acceptRange{{mediaType: "*/*", q: 1}}
+	}
+
+	var best acceptRange
+	for _, mediaType := range reg.preferred(contentType) {
+		q, index := quality(mediaType, ranges)
+		if q > best.q || (q == best.q && q > 0 && index < best.index) {
+			accept, best = mediaType, acceptRange{q: q, index: index}
+		}
+	}
+	if best.q == 0 {
+		return contentType, "", &HTTPStatusError{
+			Status: http.StatusNotAcceptable,
+			Err:    fmt.Errorf("Not Acceptable: %s", header),
+		}
+	}
+	return contentType, accept, nil
+}
+
+// requestContentType returns the media type of the request body of r without its parameters.
+func requestContentType(r *http.Request) string {
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return contentType
+}
+
+// preferred returns the media types of r, contentType and application/json first.
+func (r *Registry) preferred(contentType string) []string {
+	r.locker.RLock()
+	defer r.locker.RUnlock()
+	mediaTypes := make([]string, 0, len(r.marshalers))
+	for mediaType := range r.marshalers {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	rank := func(mediaType string) int {
+		switch mediaType {
+		case contentType:
+			return 0
+		case "application/json":
+			return 1
+		}
+		return 2
+	}
+	sort.Slice(mediaTypes, func(i, j int) bool {
+		if ri, rj := rank(mediaTypes[i]), rank(mediaTypes[j]); ri != rj {
+			return ri < rj
+		}
+		return mediaTypes[i] < mediaTypes[j]
+	})
+	return mediaTypes
+}
+
+// acceptRange is a media range of the Accept header, with its quality value and its
+// position in the header.
+type acceptRange struct {
+	mediaType string
+	q         float64
+	index     int
+}
+
+// parseAccept returns the media ranges of the Accept header value. Malformed ranges
+// are skipped. Parameters other than q are ignored.
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for i, s := range strings.Split(header, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		mediaType, params, err := mime.ParseMediaType(s)
+		if err != nil || !strings.Contains(mediaType, "/") {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q, index: i})
+	}
+	return ranges
+}
+
+// quality returns the quality value of mediaType given by the most specific of ranges
+// matching it, and the position of that range. It returns 0 when none matches.
+func quality(mediaType string, ranges []acceptRange) (float64, int) {
+	var (
+		q           float64
+		index       int
+		specificity int
+	)
+	for _, r := range ranges {
+		s := 0
+		switch {
+		case r.mediaType == mediaType:
+			s = 3
+		case strings.HasSuffix(r.mediaType, "/*") && r.mediaType != "*/*" &&
+			strings.HasPrefix(mediaType, strings.TrimSuffix(r.mediaType, "*")):
+			s = 2
+		case r.mediaType == "*/*":
+			s = 1
+		}
+		if s > specificity {
+			q, index, specificity = r.q, r.index, s
+		}
+	}
+	return q, index
+}
@@ -0,0 +1,109 @@
+package runtime_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/weblfe/protoc-gen-api/pkg/runtime"
+)
+
+func TestContentTypes(t *testing.T) {
+	for _, spec := range []struct {
+		contentType string
+		accept      string
+		wantContent string
+		wantAccept  string
+	}{
+		{
+			wantAccept: "application/json",
+		},
+		{
+			contentType: "application/protobuf",
+			wantContent: "application/protobuf",
+			wantAccept:  "application/protobuf",
+		},
+		{
+			contentType: "text/plain",
+			wantContent: "text/plain",
+			wantAccept:  "application/json",
+		},
+		{
+			contentType: "application/json; charset=utf-8",
+			accept:      "*/*",
+			wantContent: "application/json",
+			wantAccept:  "application/json",
+		},
+		{
+			contentType: "application/json",
+			accept:      "application/x-protobuf,application/json",
+			wantContent: "application/json",
+			wantAccept:  "application/x-protobuf",
+		},
+		{
+			accept:     "application/json; q=0.9, application/protobuf",
+			wantAccept: "application/protobuf",
+		},
+		{
+			accept:     "text/html,application/json",
+			wantAccept: "application/json",
+		},
+		{
+			accept:     "application/json; charset=utf-8",
+			wantAccept: "application/json",
+		},
+		{
+			contentType: "application/x-protobuf",
+			accept:      "application/*",
+			wantContent: "application/x-protobuf",
+			wantAccept:  "application/x-protobuf",
+		},
+		{
+			accept:     "application/*;q=0.5, application/json;q=0",
+			wantAccept: "application/protobuf",
+		},
+		{
+			accept:     "text/html, */*;q=0.1",
+			wantAccept: "application/json",
+		},
+		{
+			accept:     "application/protobuf;q=2, application/json;q=0.2",
+			wantAccept: "application/json",
+		},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Content-Type", spec.contentType)
+		r.Header.Set("Accept", spec.accept)
+		contentType, accept, err := runtime.NewRegistry().ContentTypes(r)
+		if err != nil || contentType != spec.wantContent || accept != spec.wantAccept {
+			t.Errorf("ContentTypes(%q, %q) = %q, %q, %v; want %q, %q", spec.contentType, spec.accept, contentType, accept, err, spec.wantContent, spec.wantAccept)
+		}
+	}
+}
+
+func TestContentTypesNotAcceptable(t *testing.T) {
+	for _, accept := range []string{
+		"text/html",
+		"application/xml, text/*",
+		"application/json;q=0, application/*;q=0",
+		"*/*;q=0",
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept", accept)
+		_, _, err := runtime.NewRegistry().ContentTypes(r)
+		var httpErr *runtime.HTTPStatusError
+		if !errors.As(err, &httpErr) || httpErr.Status != http.StatusNotAcceptable {
+			t.Errorf("ContentTypes(%q) = %v; want %d", accept, err, http.StatusNotAcceptable)
+		}
+	}
+
+	// Media types added to the Registry are negotiated.
+	reg := runtime.NewRegistry()
+	reg.Add("text/html", &runtime.JSONMarshaler{})
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept", "text/html")
+	if _, accept, err := reg.ContentTypes(r); err != nil || accept != "text/html" {
+		t.Errorf("ContentTypes(%q) = %q, %v; want %q", "text/html", accept, err, "text/html")
+	}
+}
@@ -0,0 +1,66 @@
+package runtime
+
+import (
+	"net/http"
+)
+
+// ResponseWriter is the http.ResponseWriter given to the callbacks by the generated
+// handlers. It writes a single status code, and records whether the response is
+// committed, that is whether its status code has been written.
+type ResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+// NewResponseWriter returns w as a *ResponseWriter, wrapping it unless it is one already.
+func NewResponseWriter(w http.ResponseWriter) *ResponseWriter {
+	if rw, ok := w.(*ResponseWriter); ok {
+		return rw
+	}
+	return &ResponseWriter{ResponseWriter: w}
+}
+
+// WriteHeader writes the status code, unless the response is already committed.
+func (w *ResponseWriter) WriteHeader(code int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes b to the body, committing the response with 200 OK unless it is committed.
+func (w *ResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends the data written so far when the wrapped http.ResponseWriter is an http.Flusher.
+func (w *ResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.WriteHeader(http.StatusOK)
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped http.ResponseWriter.
+func (w *ResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Committed reports whether the status code of the response has been written.
+func (w *ResponseWriter) Committed() bool {
+	return w.status != 0
+}
+
+// Status returns the status code of the response, or 0 when it is not committed.
+func (w *ResponseWriter) Status() int {
+	return w.status
+}
+
+// Committed reports whether the response written with w is committed. It is always
+// false for an http.ResponseWriter other than a *ResponseWriter, whose state is unknown.
+func Committed(w http.ResponseWriter) bool {
+	rw, ok := w.(*ResponseWriter)
+	return ok && rw.Committed()
+}
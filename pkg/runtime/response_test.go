@@ -0,0 +1,57 @@
+package runtime_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/weblfe/protoc-gen-api/pkg/runtime"
+)
+
+func TestResponseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := runtime.NewResponseWriter(rec)
+	if runtime.NewResponseWriter(w) != w {
+		t.Errorf("NewResponseWriter(*ResponseWriter) wraps it again")
+	}
+	if w.Committed() || runtime.Committed(w) || w.Status() != 0 {
+		t.Errorf("new ResponseWriter is committed with %d", w.Status())
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.WriteHeader(http.StatusInternalServerError)
+	if _, err := w.Write([]byte("body")); err != nil {
+		t.Fatal(err)
+	}
+	if !w.Committed() || !runtime.Committed(w) || w.Status() != http.StatusCreated || rec.Code != http.StatusCreated {
+		t.Errorf("ResponseWriter wrote %d, Status() = %d; want %d", rec.Code, w.Status(), http.StatusCreated)
+	}
+
+	// Writing the body commits the response with 200 OK.
+	rec = httptest.NewRecorder()
+	w = runtime.NewResponseWriter(rec)
+	if _, err := w.Write([]byte("body")); err != nil {
+		t.Fatal(err)
+	}
+	if w.Status() != http.StatusOK || rec.Code != http.StatusOK {
+		t.Errorf("ResponseWriter.Write wrote %d, Status() = %d; want %d", rec.Code, w.Status(), http.StatusOK)
+	}
+
+	if runtime.Committed(httptest.NewRecorder()) {
+		t.Errorf("Committed(ResponseRecorder) = true")
+	}
+}
+
+func TestWriteErrorCommitted(t *testing.T) {
+	rec := httptest.NewRecorder()
+	w := runtime.NewResponseWriter(rec)
+	if _, err := w.Write([]byte("partial")); err != nil {
+		t.Fatal(err)
+	}
+	runtime.WriteError(context.Background(), w, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("failed"))
+	if rec.Code != http.StatusOK || rec.Body.String() != "partial" {
+		t.Errorf("WriteError after committing wrote %d %q; want the committed response", rec.Code, rec.Body.String())
+	}
+}
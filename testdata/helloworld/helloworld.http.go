@@ -4,19 +4,13 @@
 package helloworldpb
 
 import (
-	bytes "bytes"
 	context "context"
-	fmt "fmt"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	protojson "google.golang.org/protobuf/encoding/protojson"
 	proto "google.golang.org/protobuf/proto"
-	io "io"
-	ioutil "io/ioutil"
-	mime "mime"
 	http "net/http"
-	strings "strings"
 )
 
 // GreeterHTTPService is the server API for Greeter service.
@@ -27,116 +21,61 @@ type GreeterHTTPService interface {
 
 // GreeterHTTPConverter has a function to convert GreeterHTTPService interface to http.HandlerFunc.
 type GreeterHTTPConverter struct {
-	srv GreeterHTTPService
+	srv        GreeterHTTPService
+	marshalers *runtime.Registry
 }
 
 // NewGreeterHTTPConverter returns GreeterHTTPConverter.
-func NewGreeterHTTPConverter(srv GreeterHTTPService) *GreeterHTTPConverter {
+// The options configure the marshalers of the request and response bodies.
+func NewGreeterHTTPConverter(srv GreeterHTTPService, opts ...runtime.Option) *GreeterHTTPConverter {
 	return &GreeterHTTPConverter{
-		srv: srv,
+		srv:        srv,
+		marshalers: runtime.NewRegistry(opts...),
 	}
 }
 
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the GreeterHTTPConverter.
+func (h *GreeterHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *GreeterHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
 // SayHello returns GreeterHTTPService interface's SayHello converted to http.HandlerFunc.
 //
 // SayHello says hello.
 func (h *GreeterHTTPConverter) SayHello(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &HelloRequest{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-		}
-
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
 		}
 
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/helloworld.Greeter/SayHello",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.SayHello(c, req.(*HelloRequest))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -144,34 +83,11 @@ func (h *GreeterHTTPConverter) SayHello(cb func(ctx context.Context, w http.Resp
 
 		ret, ok := iret.(*HelloReply)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/helloworld.Greeter/SayHello: interceptors have not return HelloReply"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/helloworld.Greeter/SayHello: interceptors have not return HelloReply"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
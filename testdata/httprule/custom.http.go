@@ -0,0 +1,274 @@
+// Code generated by protoc-gen-api. v1.0.0
+// source: httprule/custom.proto
+
+package httprulepb
+
+import (
+	context "context"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	proto "google.golang.org/protobuf/proto"
+	http "net/http"
+	url "net/url"
+	strings "strings"
+)
+
+// CustomHTTPService is the server API for Custom service.
+type CustomHTTPService interface {
+	HeadThing(context.Context, *GetThingRequest) (*Thing, error)
+	SearchThings(context.Context, *SearchThingsRequest) (*Thing, error)
+}
+
+// CustomHTTPConverter has a function to convert CustomHTTPService interface to http.HandlerFunc.
+type CustomHTTPConverter struct {
+	srv        CustomHTTPService
+	marshalers *runtime.Registry
+}
+
+// NewCustomHTTPConverter returns CustomHTTPConverter.
+// The options configure the marshalers of the request and response bodies.
+func NewCustomHTTPConverter(srv CustomHTTPService, opts ...runtime.Option) *CustomHTTPConverter {
+	return &CustomHTTPConverter{
+		srv:        srv,
+		marshalers: runtime.NewRegistry(opts...),
+	}
+}
+
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the CustomHTTPConverter.
+func (h *CustomHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *CustomHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
+// HeadThing returns CustomHTTPService interface's HeadThing converted to http.HandlerFunc.
+func (h *CustomHTTPConverter) HeadThing(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetThingRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Custom/HeadThing",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.HeadThing(c, req.(*GetThingRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Thing)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Custom/HeadThing: interceptors have not return Thing"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// HeadThingWithName returns Service name, Method name and CustomHTTPService interface's HeadThing converted to http.HandlerFunc.
+func (h *CustomHTTPConverter) HeadThingWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Custom", "HeadThing", h.HeadThing(cb, interceptors...)
+}
+
+// HeadThingHTTPRule returns HTTP method, path and CustomHTTPService interface's HeadThing converted to http.HandlerFunc.
+func (h *CustomHTTPConverter) HeadThingHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodHead, "/v1/things/{thing_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetThingRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 3 || p[0] != "v1" || p[1] != "things" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/things/{thing_id}"))
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "thing_id", Err: err})
+			return
+		} else {
+			arg.ThingId = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Custom/HeadThing",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.HeadThing(c, req.(*GetThingRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Thing)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Custom/HeadThing: interceptors have not return Thing"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// SearchThings returns CustomHTTPService interface's SearchThings converted to http.HandlerFunc.
+func (h *CustomHTTPConverter) SearchThings(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &SearchThingsRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Custom/SearchThings",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.SearchThings(c, req.(*SearchThingsRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Thing)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Custom/SearchThings: interceptors have not return Thing"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// SearchThingsWithName returns Service name, Method name and CustomHTTPService interface's SearchThings converted to http.HandlerFunc.
+func (h *CustomHTTPConverter) SearchThingsWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Custom", "SearchThings", h.SearchThings(cb, interceptors...)
+}
+
+// SearchThingsHTTPRule returns HTTP method, path and CustomHTTPService interface's SearchThings converted to http.HandlerFunc.
+func (h *CustomHTTPConverter) SearchThingsHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return "SEARCH", "/v1/things", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &SearchThingsRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 2 || p[0] != "v1" || p[1] != "things" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/things"))
+			return
+		}
+
+		if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Custom/SearchThings",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.SearchThings(c, req.(*SearchThingsRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Thing)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Custom/SearchThings: interceptors have not return Thing"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
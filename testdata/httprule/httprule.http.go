@@ -4,19 +4,14 @@
 package httprulepb
 
 import (
-	bytes "bytes"
 	context "context"
-	fmt "fmt"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	protojson "google.golang.org/protobuf/encoding/protojson"
 	proto "google.golang.org/protobuf/proto"
-	io "io"
-	ioutil "io/ioutil"
-	mime "mime"
 	http "net/http"
-	reflect "reflect"
+	url "net/url"
 	strconv "strconv"
 	strings "strings"
 )
@@ -24,120 +19,69 @@ import (
 // MessagingHTTPService is the server API for Messaging service.
 type MessagingHTTPService interface {
 	GetMessage(context.Context, *GetMessageRequest) (*Message, error)
+	GetMessageText(context.Context, *GetMessageRequest) (*Message, error)
+	ListMessages(context.Context, *ListMessagesRequest) (*ListMessagesResponse, error)
 	UpdateMessage(context.Context, *UpdateMessageRequest) (*Message, error)
+	UpdateMessageText(context.Context, *UpdateMessageTextRequest) (*Message, error)
+	DeleteMessage(context.Context, *DeleteMessageRequest) (*Message, error)
 	SubFieldMessage(context.Context, *SubFieldMessageRequest) (*Message, error)
 }
 
 // MessagingHTTPConverter has a function to convert MessagingHTTPService interface to http.HandlerFunc.
 type MessagingHTTPConverter struct {
-	srv MessagingHTTPService
+	srv        MessagingHTTPService
+	marshalers *runtime.Registry
 }
 
 // NewMessagingHTTPConverter returns MessagingHTTPConverter.
-func NewMessagingHTTPConverter(srv MessagingHTTPService) *MessagingHTTPConverter {
+// The options configure the marshalers of the request and response bodies.
+func NewMessagingHTTPConverter(srv MessagingHTTPService, opts ...runtime.Option) *MessagingHTTPConverter {
 	return &MessagingHTTPConverter{
-		srv: srv,
+		srv:        srv,
+		marshalers: runtime.NewRegistry(opts...),
 	}
 }
 
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the MessagingHTTPConverter.
+func (h *MessagingHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *MessagingHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
 // GetMessage returns MessagingHTTPService interface's GetMessage converted to http.HandlerFunc.
 func (h *MessagingHTTPConverter) GetMessage(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &GetMessageRequest{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/httprule.Messaging/GetMessage",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.GetMessage(c, req.(*GetMessageRequest))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -145,34 +89,11 @@ func (h *MessagingHTTPConverter) GetMessage(cb func(ctx context.Context, w http.
 
 		ret, ok := iret.(*Message)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/httprule.Messaging/GetMessage: interceptors have not return Message"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/GetMessage: interceptors have not return Message"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -188,92 +109,61 @@ func (h *MessagingHTTPConverter) GetMessageWithName(cb func(ctx context.Context,
 // GetMessageHTTPRule returns HTTP method, path and MessagingHTTPService interface's GetMessage converted to http.HandlerFunc.
 func (h *MessagingHTTPConverter) GetMessageHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.MethodGet, "/v1/messages/{message_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &GetMessageRequest{}
-		if r.Method == http.MethodGet {
-			if v := r.URL.Query().Get("revision"); v != "" {
-				c, err := strconv.ParseInt(v, 10, 64)
-				if err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-				arg.Revision = c
-			}
-			if v := r.URL.Query().Get("sub.subfield"); v != "" {
-				arg.Sub.Subfield = v
-			}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 3 || p[0] != "v1" || p[1] != "messages" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/messages/{message_id}"))
+			return
 		}
 
-		p := strings.Split(r.URL.Path, "/")
-		arg.MessageId = p[3]
-
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
+		if v := r.URL.Query().Get("revision"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "revision", Err: err})
+				return
 			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
+			arg.Revision = c
+		}
+		if v := r.URL.Query().Get("sub.subfield"); v != "" {
+			if arg.Sub == nil {
+				arg.Sub = &GetMessageRequest_SubMessage{}
 			}
-			return chainedHandler(ctx, arg)
+			arg.Sub.Subfield = v
+		}
+		if v := r.URL.Query().Get("user_id"); v != "" {
+			arg.UserId = v
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "message_id", Err: err})
+			return
+		} else {
+			arg.MessageId = v
 		}
 
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/httprule.Messaging/GetMessage",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.GetMessage(c, req.(*GetMessageRequest))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -281,34 +171,11 @@ func (h *MessagingHTTPConverter) GetMessageHTTPRule(cb func(ctx context.Context,
 
 		ret, ok := iret.(*Message)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/httprule.Messaging/GetMessage: interceptors have not return Message"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/GetMessage: interceptors have not return Message"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -316,104 +183,67 @@ func (h *MessagingHTTPConverter) GetMessageHTTPRule(cb func(ctx context.Context,
 	})
 }
 
-// UpdateMessage returns MessagingHTTPService interface's UpdateMessage converted to http.HandlerFunc.
-func (h *MessagingHTTPConverter) UpdateMessage(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+// GetMessageHTTPRule1 returns HTTP method, path and MessagingHTTPService interface's GetMessage converted to http.HandlerFunc for additional binding 1.
+func (h *MessagingHTTPConverter) GetMessageHTTPRule1(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+	return http.MethodGet, "/v1/users/{user_id}/messages/{message_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
+		arg := &GetMessageRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 5 || p[0] != "v1" || p[1] != "users" || p[3] != "messages" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/users/{user_id}/messages/{message_id}"))
+			return
+		}
 
-		arg := &UpdateMessageRequest{}
-		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
+		if v := r.URL.Query().Get("revision"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
 			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
-				cb(ctx, w, r, nil, nil, err)
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "revision", Err: err})
 				return
 			}
+			arg.Revision = c
 		}
-
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
+		if v := r.URL.Query().Get("sub.subfield"); v != "" {
+			if arg.Sub == nil {
+				arg.Sub = &GetMessageRequest_SubMessage{}
 			}
+			arg.Sub.Subfield = v
+		}
 
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
+		if v, err := url.PathUnescape(p[4]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "message_id", Err: err})
+			return
+		} else {
+			arg.MessageId = v
+		}
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "user_id", Err: err})
+			return
+		} else {
+			arg.UserId = v
 		}
 
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
-			FullMethod: "/httprule.Messaging/UpdateMessage",
+			FullMethod: "/httprule.Messaging/GetMessage",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
-			return h.srv.UpdateMessage(c, req.(*UpdateMessageRequest))
+			return h.srv.GetMessage(c, req.(*GetMessageRequest))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -421,34 +251,11 @@ func (h *MessagingHTTPConverter) UpdateMessage(cb func(ctx context.Context, w ht
 
 		ret, ok := iret.(*Message)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/httprule.Messaging/UpdateMessage: interceptors have not return Message"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/GetMessage: interceptors have not return Message"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -456,112 +263,64 @@ func (h *MessagingHTTPConverter) UpdateMessage(cb func(ctx context.Context, w ht
 	})
 }
 
-// UpdateMessageWithName returns Service name, Method name and MessagingHTTPService interface's UpdateMessage converted to http.HandlerFunc.
-func (h *MessagingHTTPConverter) UpdateMessageWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
-	return "Messaging", "UpdateMessage", h.UpdateMessage(cb, interceptors...)
-}
-
-// UpdateMessageHTTPRule returns HTTP method, path and MessagingHTTPService interface's UpdateMessage converted to http.HandlerFunc.
-func (h *MessagingHTTPConverter) UpdateMessageHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+// GetMessageHTTPRule2 returns HTTP method, path and MessagingHTTPService interface's GetMessage converted to http.HandlerFunc for additional binding 2.
+func (h *MessagingHTTPConverter) GetMessageHTTPRule2(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
-	return http.MethodPut, "/v1/messages/{message_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return http.MethodGet, "/v1/inbox/{message_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
+		arg := &GetMessageRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 3 || p[0] != "v1" || p[1] != "inbox" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/inbox/{message_id}"))
+			return
+		}
 
-		arg := &UpdateMessageRequest{}
-		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
+		if v := r.URL.Query().Get("revision"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
 			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
-				cb(ctx, w, r, nil, nil, err)
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "revision", Err: err})
 				return
 			}
+			arg.Revision = c
 		}
-
-		p := strings.Split(r.URL.Path, "/")
-		arg.MessageId = p[3]
-
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
+		if v := r.URL.Query().Get("sub.subfield"); v != "" {
+			if arg.Sub == nil {
+				arg.Sub = &GetMessageRequest_SubMessage{}
 			}
+			arg.Sub.Subfield = v
+		}
+		if v := r.URL.Query().Get("user_id"); v != "" {
+			arg.UserId = v
+		}
 
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "message_id", Err: err})
+			return
+		} else {
+			arg.MessageId = v
 		}
 
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
-			FullMethod: "/httprule.Messaging/UpdateMessage",
+			FullMethod: "/httprule.Messaging/GetMessage",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
-			return h.srv.UpdateMessage(c, req.(*UpdateMessageRequest))
+			return h.srv.GetMessage(c, req.(*GetMessageRequest))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -569,34 +328,11 @@ func (h *MessagingHTTPConverter) UpdateMessageHTTPRule(cb func(ctx context.Conte
 
 		ret, ok := iret.(*Message)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/httprule.Messaging/UpdateMessage: interceptors have not return Message"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/GetMessage: interceptors have not return Message"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -604,104 +340,39 @@ func (h *MessagingHTTPConverter) UpdateMessageHTTPRule(cb func(ctx context.Conte
 	})
 }
 
-// SubFieldMessage returns MessagingHTTPService interface's SubFieldMessage converted to http.HandlerFunc.
-func (h *MessagingHTTPConverter) SubFieldMessage(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+// GetMessageText returns MessagingHTTPService interface's GetMessageText converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) GetMessageText(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
-		arg := &SubFieldMessageRequest{}
+		arg := &GetMessageRequest{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
-			FullMethod: "/httprule.Messaging/SubFieldMessage",
+			FullMethod: "/httprule.Messaging/GetMessageText",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
-			return h.srv.SubFieldMessage(c, req.(*SubFieldMessageRequest))
+			return h.srv.GetMessageText(c, req.(*GetMessageRequest))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -709,34 +380,11 @@ func (h *MessagingHTTPConverter) SubFieldMessage(cb func(ctx context.Context, w
 
 		ret, ok := iret.(*Message)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/httprule.Messaging/SubFieldMessage: interceptors have not return Message"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/GetMessageText: interceptors have not return Message"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -744,114 +392,69 @@ func (h *MessagingHTTPConverter) SubFieldMessage(cb func(ctx context.Context, w
 	})
 }
 
-// SubFieldMessageWithName returns Service name, Method name and MessagingHTTPService interface's SubFieldMessage converted to http.HandlerFunc.
-func (h *MessagingHTTPConverter) SubFieldMessageWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
-	return "Messaging", "SubFieldMessage", h.SubFieldMessage(cb, interceptors...)
+// GetMessageTextWithName returns Service name, Method name and MessagingHTTPService interface's GetMessageText converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) GetMessageTextWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Messaging", "GetMessageText", h.GetMessageText(cb, interceptors...)
 }
 
-// SubFieldMessageHTTPRule returns HTTP method, path and MessagingHTTPService interface's SubFieldMessage converted to http.HandlerFunc.
-func (h *MessagingHTTPConverter) SubFieldMessageHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+// GetMessageTextHTTPRule returns HTTP method, path and MessagingHTTPService interface's GetMessageText converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) GetMessageTextHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
-	return http.MethodPost, "/v1/messages/{message_id}/{sub.subfield}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+	return http.MethodGet, "/v1/messages/{message_id}/text", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
+		arg := &GetMessageRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 4 || p[0] != "v1" || p[1] != "messages" || p[3] != "text" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/messages/{message_id}/text"))
+			return
+		}
 
-		arg := &SubFieldMessageRequest{}
-		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
+		if v := r.URL.Query().Get("revision"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
 			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
-				cb(ctx, w, r, nil, nil, err)
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "revision", Err: err})
 				return
 			}
+			arg.Revision = c
 		}
-
-		p := strings.Split(r.URL.Path, "/")
-		arg.MessageId = p[3]
-		reflect.ValueOf(&arg.Sub).Elem().Set(reflect.ValueOf(reflect.New(reflect.TypeOf(arg.Sub).Elem()).Interface()))
-		arg.Sub.Subfield = p[4]
-
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
+		if v := r.URL.Query().Get("sub.subfield"); v != "" {
+			if arg.Sub == nil {
+				arg.Sub = &GetMessageRequest_SubMessage{}
 			}
+			arg.Sub.Subfield = v
+		}
+		if v := r.URL.Query().Get("user_id"); v != "" {
+			arg.UserId = v
+		}
 
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "message_id", Err: err})
+			return
+		} else {
+			arg.MessageId = v
 		}
 
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
-			FullMethod: "/httprule.Messaging/SubFieldMessage",
+			FullMethod: "/httprule.Messaging/GetMessageText",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
-			return h.srv.SubFieldMessage(c, req.(*SubFieldMessageRequest))
+			return h.srv.GetMessageText(c, req.(*GetMessageRequest))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -859,34 +462,626 @@ func (h *MessagingHTTPConverter) SubFieldMessageHTTPRule(cb func(ctx context.Con
 
 		ret, ok := iret.(*Message)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/httprule.Messaging/SubFieldMessage: interceptors have not return Message"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/GetMessageText: interceptors have not return Message"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
+		if err := h.marshalers.WriteResponse(w, accept, ret, "text"); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// ListMessages returns MessagingHTTPService interface's ListMessages converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) ListMessages(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListMessagesRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
 				return
 			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/ListMessages",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListMessages(c, req.(*ListMessagesRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*ListMessagesResponse)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/ListMessages: interceptors have not return ListMessagesResponse"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// ListMessagesWithName returns Service name, Method name and MessagingHTTPService interface's ListMessages converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) ListMessagesWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Messaging", "ListMessages", h.ListMessages(cb, interceptors...)
+}
+
+// ListMessagesHTTPRule returns HTTP method, path and MessagingHTTPService interface's ListMessages converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) ListMessagesHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/messages", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListMessagesRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 2 || p[0] != "v1" || p[1] != "messages" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/messages"))
+			return
+		}
+
+		if v := r.URL.Query().Get("page_size"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "page_size", Err: err})
+				return
+			}
+			arg.PageSize = int32(c)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/ListMessages",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListMessages(c, req.(*ListMessagesRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*ListMessagesResponse)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/ListMessages: interceptors have not return ListMessagesResponse"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, "messages"); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// UpdateMessage returns MessagingHTTPService interface's UpdateMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) UpdateMessage(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &UpdateMessageRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/UpdateMessage",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.UpdateMessage(c, req.(*UpdateMessageRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Message)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/UpdateMessage: interceptors have not return Message"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// UpdateMessageWithName returns Service name, Method name and MessagingHTTPService interface's UpdateMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) UpdateMessageWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Messaging", "UpdateMessage", h.UpdateMessage(cb, interceptors...)
+}
+
+// UpdateMessageHTTPRule returns HTTP method, path and MessagingHTTPService interface's UpdateMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) UpdateMessageHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodPut, "/v1/messages/{message_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &UpdateMessageRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 3 || p[0] != "v1" || p[1] != "messages" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/messages/{message_id}"))
+			return
+		}
+
+		if err := h.marshalers.UnmarshalRequest(r, contentType, arg, "message"); err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "message_id", Err: err})
+			return
+		} else {
+			arg.MessageId = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/UpdateMessage",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.UpdateMessage(c, req.(*UpdateMessageRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Message)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/UpdateMessage: interceptors have not return Message"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// UpdateMessageText returns MessagingHTTPService interface's UpdateMessageText converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) UpdateMessageText(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &UpdateMessageTextRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/UpdateMessageText",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.UpdateMessageText(c, req.(*UpdateMessageTextRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Message)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/UpdateMessageText: interceptors have not return Message"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// UpdateMessageTextWithName returns Service name, Method name and MessagingHTTPService interface's UpdateMessageText converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) UpdateMessageTextWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Messaging", "UpdateMessageText", h.UpdateMessageText(cb, interceptors...)
+}
+
+// UpdateMessageTextHTTPRule returns HTTP method, path and MessagingHTTPService interface's UpdateMessageText converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) UpdateMessageTextHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodPatch, "/v1/messages/{message_id}/text", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &UpdateMessageTextRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 4 || p[0] != "v1" || p[1] != "messages" || p[3] != "text" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/messages/{message_id}/text"))
+			return
+		}
+
+		if err := h.marshalers.UnmarshalRequest(r, contentType, arg, "text"); err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "message_id", Err: err})
+			return
+		} else {
+			arg.MessageId = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/UpdateMessageText",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.UpdateMessageText(c, req.(*UpdateMessageTextRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Message)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/UpdateMessageText: interceptors have not return Message"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// DeleteMessage returns MessagingHTTPService interface's DeleteMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) DeleteMessage(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &DeleteMessageRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/DeleteMessage",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.DeleteMessage(c, req.(*DeleteMessageRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Message)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/DeleteMessage: interceptors have not return Message"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// DeleteMessageWithName returns Service name, Method name and MessagingHTTPService interface's DeleteMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) DeleteMessageWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Messaging", "DeleteMessage", h.DeleteMessage(cb, interceptors...)
+}
+
+// DeleteMessageHTTPRule returns HTTP method, path and MessagingHTTPService interface's DeleteMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) DeleteMessageHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodDelete, "/v1/messages/{message_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &DeleteMessageRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 3 || p[0] != "v1" || p[1] != "messages" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/messages/{message_id}"))
+			return
+		}
+
+		if v := r.URL.Query().Get("force"); v != "" {
+			c, err := strconv.ParseBool(v)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "force", Err: err})
+				return
+			}
+			arg.Force = c
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "message_id", Err: err})
+			return
+		} else {
+			arg.MessageId = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/DeleteMessage",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.DeleteMessage(c, req.(*DeleteMessageRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Message)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/DeleteMessage: interceptors have not return Message"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// SubFieldMessage returns MessagingHTTPService interface's SubFieldMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) SubFieldMessage(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &SubFieldMessageRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/SubFieldMessage",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.SubFieldMessage(c, req.(*SubFieldMessageRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Message)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/SubFieldMessage: interceptors have not return Message"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// SubFieldMessageWithName returns Service name, Method name and MessagingHTTPService interface's SubFieldMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) SubFieldMessageWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Messaging", "SubFieldMessage", h.SubFieldMessage(cb, interceptors...)
+}
+
+// SubFieldMessageHTTPRule returns HTTP method, path and MessagingHTTPService interface's SubFieldMessage converted to http.HandlerFunc.
+func (h *MessagingHTTPConverter) SubFieldMessageHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodPost, "/v1/messages/{message_id}/{sub.subfield}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &SubFieldMessageRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 4 || p[0] != "v1" || p[1] != "messages" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/messages/{message_id}/{sub.subfield}"))
+			return
+		}
+
+		if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "message_id", Err: err})
+			return
+		} else {
+			arg.MessageId = v
+		}
+		if v, err := url.PathUnescape(p[3]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "sub.subfield", Err: err})
+			return
+		} else {
+			if arg.Sub == nil {
+				arg.Sub = &SubFieldMessageRequest_SubMessage{}
+			}
+			arg.Sub.Subfield = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Messaging/SubFieldMessage",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.SubFieldMessage(c, req.(*SubFieldMessageRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Message)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Messaging/SubFieldMessage: interceptors have not return Message"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
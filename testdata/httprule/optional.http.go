@@ -0,0 +1,211 @@
+// Code generated by protoc-gen-api. v1.0.0
+// source: httprule/optional.proto
+
+package httprulepb
+
+import (
+	context "context"
+	base64 "encoding/base64"
+	fmt "fmt"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	proto "google.golang.org/protobuf/proto"
+	http "net/http"
+	url "net/url"
+	strconv "strconv"
+	strings "strings"
+)
+
+// OptionalHTTPService is the server API for Optional service.
+type OptionalHTTPService interface {
+	ListItems(context.Context, *ListItemsRequest) (*ListItemsRequest, error)
+}
+
+// OptionalHTTPConverter has a function to convert OptionalHTTPService interface to http.HandlerFunc.
+type OptionalHTTPConverter struct {
+	srv        OptionalHTTPService
+	marshalers *runtime.Registry
+}
+
+// NewOptionalHTTPConverter returns OptionalHTTPConverter.
+// The options configure the marshalers of the request and response bodies.
+func NewOptionalHTTPConverter(srv OptionalHTTPService, opts ...runtime.Option) *OptionalHTTPConverter {
+	return &OptionalHTTPConverter{
+		srv:        srv,
+		marshalers: runtime.NewRegistry(opts...),
+	}
+}
+
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the OptionalHTTPConverter.
+func (h *OptionalHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *OptionalHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
+// ListItems returns OptionalHTTPService interface's ListItems converted to http.HandlerFunc.
+func (h *OptionalHTTPConverter) ListItems(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListItemsRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Optional/ListItems",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListItems(c, req.(*ListItemsRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*ListItemsRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Optional/ListItems: interceptors have not return ListItemsRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// ListItemsWithName returns Service name, Method name and OptionalHTTPService interface's ListItems converted to http.HandlerFunc.
+func (h *OptionalHTTPConverter) ListItemsWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Optional", "ListItems", h.ListItems(cb, interceptors...)
+}
+
+// ListItemsHTTPRule returns HTTP method, path and OptionalHTTPService interface's ListItems converted to http.HandlerFunc.
+func (h *OptionalHTTPConverter) ListItemsHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/shelves/{shelf}/items", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListItemsRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 4 || p[0] != "v1" || p[1] != "shelves" || p[3] != "items" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/shelves/{shelf}/items"))
+			return
+		}
+
+		if v := r.URL.Query().Get("page_size"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "page_size", Err: err})
+				return
+			}
+			arg.PageSize = proto.Int32(int32(c))
+		}
+		if v := r.URL.Query().Get("include_deleted"); v != "" {
+			c, err := strconv.ParseBool(v)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "include_deleted", Err: err})
+				return
+			}
+			arg.IncludeDeleted = proto.Bool(c)
+		}
+		if v := r.URL.Query().Get("sort"); v != "" {
+			c, ok := Sort_value[v]
+			if !ok {
+				n, err := strconv.ParseInt(v, 10, 32)
+				if _, known := Sort_name[int32(n)]; err != nil || !known {
+					err = fmt.Errorf("invalid value %q for enum httprule.Sort", v)
+					cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "sort", Err: err})
+					return
+				}
+				c = int32(n)
+			}
+			arg.Sort = Sort(c).Enum()
+		}
+		if v := r.URL.Query().Get("min_price"); v != "" {
+			c, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "min_price", Err: err})
+				return
+			}
+			arg.MinPrice = proto.Float64(c)
+		}
+		if v := r.URL.Query().Get("cursor"); v != "" {
+			c, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "cursor", Err: err})
+				return
+			}
+			arg.Cursor = c
+		}
+		if v := r.URL.Query().Get("filter"); v != "" {
+			arg.Filter = proto.String(v)
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "shelf", Err: err})
+			return
+		} else {
+			arg.Shelf = proto.String(v)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.Optional/ListItems",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListItems(c, req.(*ListItemsRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*ListItemsRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.Optional/ListItems: interceptors have not return ListItemsRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
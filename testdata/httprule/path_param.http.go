@@ -0,0 +1,216 @@
+// Code generated by protoc-gen-api. v1.0.0
+// source: httprule/path_param.proto
+
+package httprulepb
+
+import (
+	context "context"
+	fmt "fmt"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	proto "google.golang.org/protobuf/proto"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	http "net/http"
+	url "net/url"
+	strconv "strconv"
+	strings "strings"
+)
+
+// PathParamHTTPService is the server API for PathParam service.
+type PathParamHTTPService interface {
+	GetUser(context.Context, *GetUserRequest) (*GetUserRequest, error)
+}
+
+// PathParamHTTPConverter has a function to convert PathParamHTTPService interface to http.HandlerFunc.
+type PathParamHTTPConverter struct {
+	srv        PathParamHTTPService
+	marshalers *runtime.Registry
+}
+
+// NewPathParamHTTPConverter returns PathParamHTTPConverter.
+// The options configure the marshalers of the request and response bodies.
+func NewPathParamHTTPConverter(srv PathParamHTTPService, opts ...runtime.Option) *PathParamHTTPConverter {
+	return &PathParamHTTPConverter{
+		srv:        srv,
+		marshalers: runtime.NewRegistry(opts...),
+	}
+}
+
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the PathParamHTTPConverter.
+func (h *PathParamHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *PathParamHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
+// GetUser returns PathParamHTTPService interface's GetUser converted to http.HandlerFunc.
+func (h *PathParamHTTPConverter) GetUser(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetUserRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.PathParam/GetUser",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetUser(c, req.(*GetUserRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*GetUserRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.PathParam/GetUser: interceptors have not return GetUserRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetUserWithName returns Service name, Method name and PathParamHTTPService interface's GetUser converted to http.HandlerFunc.
+func (h *PathParamHTTPConverter) GetUserWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "PathParam", "GetUser", h.GetUser(cb, interceptors...)
+}
+
+// GetUserHTTPRule returns HTTP method, path and PathParamHTTPService interface's GetUser converted to http.HandlerFunc.
+func (h *PathParamHTTPConverter) GetUserHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/users/{user_id}/{role}/{active}/{version}/{score}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetUserRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 7 || p[0] != "v1" || p[1] != "users" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/users/{user_id}/{role}/{active}/{version}/{score}"))
+			return
+		}
+
+		if v, err := url.PathUnescape(p[4]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "active", Err: err})
+			return
+		} else if v != "" {
+			c, err := strconv.ParseBool(v)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "active", Err: err})
+				return
+			}
+			arg.Active = c
+		}
+		if v, err := url.PathUnescape(p[3]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "role", Err: err})
+			return
+		} else if v != "" {
+			c, ok := Role_value[v]
+			if !ok {
+				n, err := strconv.ParseInt(v, 10, 32)
+				if _, known := Role_name[int32(n)]; err != nil || !known {
+					err = fmt.Errorf("invalid value %q for enum httprule.Role", v)
+					cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "role", Err: err})
+					return
+				}
+				c = int32(n)
+			}
+			arg.Role = Role(c)
+		}
+		if v, err := url.PathUnescape(p[6]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "score", Err: err})
+			return
+		} else if v != "" {
+			c, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "score", Err: err})
+				return
+			}
+			arg.Score = c
+		}
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "user_id", Err: err})
+			return
+		} else if v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "user_id", Err: err})
+				return
+			}
+			arg.UserId = c
+		}
+		if v, err := url.PathUnescape(p[5]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "version", Err: err})
+			return
+		} else if v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "version", Err: err})
+				return
+			}
+			arg.Version = &wrapperspb.Int64Value{Value: c}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.PathParam/GetUser",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetUser(c, req.(*GetUserRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*GetUserRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.PathParam/GetUser: interceptors have not return GetUserRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
@@ -0,0 +1,730 @@
+// Code generated by protoc-gen-api. v1.0.0
+// source: httprule/query_param.proto
+
+package httprulepb
+
+import (
+	context "context"
+	fmt "fmt"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protojson "google.golang.org/protobuf/encoding/protojson"
+	proto "google.golang.org/protobuf/proto"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
+	http "net/http"
+	url "net/url"
+	strconv "strconv"
+	strings "strings"
+)
+
+// QueryParamHTTPService is the server API for QueryParam service.
+type QueryParamHTTPService interface {
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersRequest, error)
+	ListOrgUsers(context.Context, *ListUsersRequest) (*ListUsersRequest, error)
+	SearchNodes(context.Context, *SearchNodesRequest) (*SearchNodesRequest, error)
+}
+
+// QueryParamHTTPConverter has a function to convert QueryParamHTTPService interface to http.HandlerFunc.
+type QueryParamHTTPConverter struct {
+	srv        QueryParamHTTPService
+	marshalers *runtime.Registry
+}
+
+// NewQueryParamHTTPConverter returns QueryParamHTTPConverter.
+// The options configure the marshalers of the request and response bodies.
+func NewQueryParamHTTPConverter(srv QueryParamHTTPService, opts ...runtime.Option) *QueryParamHTTPConverter {
+	return &QueryParamHTTPConverter{
+		srv:        srv,
+		marshalers: runtime.NewRegistry(opts...),
+	}
+}
+
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the QueryParamHTTPConverter.
+func (h *QueryParamHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *QueryParamHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
+// ListUsers returns QueryParamHTTPService interface's ListUsers converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) ListUsers(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListUsersRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.QueryParam/ListUsers",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListUsers(c, req.(*ListUsersRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*ListUsersRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.QueryParam/ListUsers: interceptors have not return ListUsersRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// ListUsersWithName returns Service name, Method name and QueryParamHTTPService interface's ListUsers converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) ListUsersWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "QueryParam", "ListUsers", h.ListUsers(cb, interceptors...)
+}
+
+// ListUsersHTTPRule returns HTTP method, path and QueryParamHTTPService interface's ListUsers converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) ListUsersHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/users", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListUsersRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 2 || p[0] != "v1" || p[1] != "users" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/users"))
+			return
+		}
+
+		if v := r.URL.Query().Get("state"); v != "" {
+			c, ok := State_value[v]
+			if !ok {
+				n, err := strconv.ParseInt(v, 10, 32)
+				if _, known := State_name[int32(n)]; err != nil || !known {
+					err = fmt.Errorf("invalid value %q for enum httprule.State", v)
+					cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "state", Err: err})
+					return
+				}
+				c = int32(n)
+			}
+			arg.State = State(c)
+		}
+		if repeated := r.URL.Query()["states"]; len(repeated) != 0 {
+			arr := make([]State, 0, len(repeated))
+			for _, v := range repeated {
+				c, ok := State_value[v]
+				if !ok {
+					n, err := strconv.ParseInt(v, 10, 32)
+					if _, known := State_name[int32(n)]; err != nil || !known {
+						err = fmt.Errorf("invalid value %q for enum httprule.State", v)
+						cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "states", Err: err})
+						return
+					}
+					c = int32(n)
+				}
+				arr = append(arr, State(c))
+			}
+			arg.States = arr
+		}
+		for k, vs := range r.URL.Query() {
+			if len(vs) == 0 || !strings.HasPrefix(k, "labels[") || !strings.HasSuffix(k, "]") {
+				continue
+			}
+			if k == "labels[]" {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: k, Err: fmt.Errorf("empty key of map labels")})
+				return
+			}
+			if arg.Labels == nil {
+				arg.Labels = make(map[string]string)
+			}
+			key := k[7 : len(k)-1]
+			{
+				v := vs[len(vs)-1]
+				arg.Labels[key] = v
+			}
+		}
+		for k, vs := range r.URL.Query() {
+			if len(vs) == 0 || !strings.HasPrefix(k, "states_by_id[") || !strings.HasSuffix(k, "]") {
+				continue
+			}
+			if k == "states_by_id[]" {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: k, Err: fmt.Errorf("empty key of map states_by_id")})
+				return
+			}
+			if arg.StatesById == nil {
+				arg.StatesById = make(map[int64]State)
+			}
+			c, err := strconv.ParseInt(k[13:len(k)-1], 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: k, Err: err})
+				return
+			}
+			key := c
+			{
+				v := vs[len(vs)-1]
+				c, ok := State_value[v]
+				if !ok {
+					n, err := strconv.ParseInt(v, 10, 32)
+					if _, known := State_name[int32(n)]; err != nil || !known {
+						err = fmt.Errorf("invalid value %q for enum httprule.State", v)
+						cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: k, Err: err})
+						return
+					}
+					c = int32(n)
+				}
+				arg.StatesById[key] = State(c)
+			}
+		}
+		if v := r.URL.Query().Get("created_after"); v != "" {
+			c := &timestamppb.Timestamp{}
+			if err := protojson.Unmarshal([]byte(strconv.Quote(v)), c); err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "created_after", Err: err})
+				return
+			}
+			arg.CreatedAfter = c
+		}
+		if v := r.URL.Query().Get("max_idle"); v != "" {
+			c := &durationpb.Duration{}
+			if err := protojson.Unmarshal([]byte(strconv.Quote(v)), c); err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "max_idle", Err: err})
+				return
+			}
+			arg.MaxIdle = c
+		}
+		if v := r.URL.Query().Get("read_mask"); v != "" {
+			c := &fieldmaskpb.FieldMask{}
+			if err := protojson.Unmarshal([]byte(strconv.Quote(v)), c); err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "read_mask", Err: err})
+				return
+			}
+			arg.ReadMask = c
+		}
+		if v := r.URL.Query().Get("min_age"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "min_age", Err: err})
+				return
+			}
+			arg.MinAge = &wrapperspb.Int32Value{Value: int32(c)}
+		}
+		if repeated := r.URL.Query()["login_times"]; len(repeated) != 0 {
+			arr := make([]*timestamppb.Timestamp, 0, len(repeated))
+			for _, v := range repeated {
+				c := &timestamppb.Timestamp{}
+				if err := protojson.Unmarshal([]byte(strconv.Quote(v)), c); err != nil {
+					cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "login_times", Err: err})
+					return
+				}
+				arr = append(arr, c)
+			}
+			arg.LoginTimes = arr
+		}
+		if v := r.URL.Query().Get("email"); v != "" {
+			if _, ok := arg.Filter.(*ListUsersRequest_Email); arg.Filter != nil && !ok {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "email", Err: fmt.Errorf("another field of oneof filter is already set")})
+				return
+			}
+			arg.Filter = &ListUsersRequest_Email{Email: v}
+		}
+		if v := r.URL.Query().Get("org_id"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "org_id", Err: err})
+				return
+			}
+			if _, ok := arg.Filter.(*ListUsersRequest_OrgId); arg.Filter != nil && !ok {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "org_id", Err: fmt.Errorf("another field of oneof filter is already set")})
+				return
+			}
+			arg.Filter = &ListUsersRequest_OrgId{OrgId: c}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.QueryParam/ListUsers",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListUsers(c, req.(*ListUsersRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*ListUsersRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.QueryParam/ListUsers: interceptors have not return ListUsersRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// ListOrgUsers returns QueryParamHTTPService interface's ListOrgUsers converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) ListOrgUsers(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListUsersRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.QueryParam/ListOrgUsers",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListOrgUsers(c, req.(*ListUsersRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*ListUsersRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.QueryParam/ListOrgUsers: interceptors have not return ListUsersRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// ListOrgUsersWithName returns Service name, Method name and QueryParamHTTPService interface's ListOrgUsers converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) ListOrgUsersWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "QueryParam", "ListOrgUsers", h.ListOrgUsers(cb, interceptors...)
+}
+
+// ListOrgUsersHTTPRule returns HTTP method, path and QueryParamHTTPService interface's ListOrgUsers converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) ListOrgUsersHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/orgs/{org_id}/users", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListUsersRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 4 || p[0] != "v1" || p[1] != "orgs" || p[3] != "users" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/orgs/{org_id}/users"))
+			return
+		}
+
+		if v := r.URL.Query().Get("state"); v != "" {
+			c, ok := State_value[v]
+			if !ok {
+				n, err := strconv.ParseInt(v, 10, 32)
+				if _, known := State_name[int32(n)]; err != nil || !known {
+					err = fmt.Errorf("invalid value %q for enum httprule.State", v)
+					cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "state", Err: err})
+					return
+				}
+				c = int32(n)
+			}
+			arg.State = State(c)
+		}
+		if repeated := r.URL.Query()["states"]; len(repeated) != 0 {
+			arr := make([]State, 0, len(repeated))
+			for _, v := range repeated {
+				c, ok := State_value[v]
+				if !ok {
+					n, err := strconv.ParseInt(v, 10, 32)
+					if _, known := State_name[int32(n)]; err != nil || !known {
+						err = fmt.Errorf("invalid value %q for enum httprule.State", v)
+						cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "states", Err: err})
+						return
+					}
+					c = int32(n)
+				}
+				arr = append(arr, State(c))
+			}
+			arg.States = arr
+		}
+		for k, vs := range r.URL.Query() {
+			if len(vs) == 0 || !strings.HasPrefix(k, "labels[") || !strings.HasSuffix(k, "]") {
+				continue
+			}
+			if k == "labels[]" {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: k, Err: fmt.Errorf("empty key of map labels")})
+				return
+			}
+			if arg.Labels == nil {
+				arg.Labels = make(map[string]string)
+			}
+			key := k[7 : len(k)-1]
+			{
+				v := vs[len(vs)-1]
+				arg.Labels[key] = v
+			}
+		}
+		for k, vs := range r.URL.Query() {
+			if len(vs) == 0 || !strings.HasPrefix(k, "states_by_id[") || !strings.HasSuffix(k, "]") {
+				continue
+			}
+			if k == "states_by_id[]" {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: k, Err: fmt.Errorf("empty key of map states_by_id")})
+				return
+			}
+			if arg.StatesById == nil {
+				arg.StatesById = make(map[int64]State)
+			}
+			c, err := strconv.ParseInt(k[13:len(k)-1], 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: k, Err: err})
+				return
+			}
+			key := c
+			{
+				v := vs[len(vs)-1]
+				c, ok := State_value[v]
+				if !ok {
+					n, err := strconv.ParseInt(v, 10, 32)
+					if _, known := State_name[int32(n)]; err != nil || !known {
+						err = fmt.Errorf("invalid value %q for enum httprule.State", v)
+						cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: k, Err: err})
+						return
+					}
+					c = int32(n)
+				}
+				arg.StatesById[key] = State(c)
+			}
+		}
+		if v := r.URL.Query().Get("created_after"); v != "" {
+			c := &timestamppb.Timestamp{}
+			if err := protojson.Unmarshal([]byte(strconv.Quote(v)), c); err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "created_after", Err: err})
+				return
+			}
+			arg.CreatedAfter = c
+		}
+		if v := r.URL.Query().Get("max_idle"); v != "" {
+			c := &durationpb.Duration{}
+			if err := protojson.Unmarshal([]byte(strconv.Quote(v)), c); err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "max_idle", Err: err})
+				return
+			}
+			arg.MaxIdle = c
+		}
+		if v := r.URL.Query().Get("read_mask"); v != "" {
+			c := &fieldmaskpb.FieldMask{}
+			if err := protojson.Unmarshal([]byte(strconv.Quote(v)), c); err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "read_mask", Err: err})
+				return
+			}
+			arg.ReadMask = c
+		}
+		if v := r.URL.Query().Get("min_age"); v != "" {
+			c, err := strconv.ParseInt(v, 10, 32)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "min_age", Err: err})
+				return
+			}
+			arg.MinAge = &wrapperspb.Int32Value{Value: int32(c)}
+		}
+		if repeated := r.URL.Query()["login_times"]; len(repeated) != 0 {
+			arr := make([]*timestamppb.Timestamp, 0, len(repeated))
+			for _, v := range repeated {
+				c := &timestamppb.Timestamp{}
+				if err := protojson.Unmarshal([]byte(strconv.Quote(v)), c); err != nil {
+					cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "login_times", Err: err})
+					return
+				}
+				arr = append(arr, c)
+			}
+			arg.LoginTimes = arr
+		}
+		if v := r.URL.Query().Get("email"); v != "" {
+			if _, ok := arg.Filter.(*ListUsersRequest_Email); arg.Filter != nil && !ok {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourceQuery, Field: "email", Err: fmt.Errorf("another field of oneof filter is already set")})
+				return
+			}
+			arg.Filter = &ListUsersRequest_Email{Email: v}
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "org_id", Err: err})
+			return
+		} else if v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "org_id", Err: err})
+				return
+			}
+			if _, ok := arg.Filter.(*ListUsersRequest_OrgId); arg.Filter != nil && !ok {
+				cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "org_id", Err: fmt.Errorf("another field of oneof filter is already set")})
+				return
+			}
+			arg.Filter = &ListUsersRequest_OrgId{OrgId: c}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.QueryParam/ListOrgUsers",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListOrgUsers(c, req.(*ListUsersRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*ListUsersRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.QueryParam/ListOrgUsers: interceptors have not return ListUsersRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// SearchNodes returns QueryParamHTTPService interface's SearchNodes converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) SearchNodes(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &SearchNodesRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.QueryParam/SearchNodes",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.SearchNodes(c, req.(*SearchNodesRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*SearchNodesRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.QueryParam/SearchNodes: interceptors have not return SearchNodesRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// SearchNodesWithName returns Service name, Method name and QueryParamHTTPService interface's SearchNodes converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) SearchNodesWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "QueryParam", "SearchNodes", h.SearchNodes(cb, interceptors...)
+}
+
+// SearchNodesHTTPRule returns HTTP method, path and QueryParamHTTPService interface's SearchNodes converted to http.HandlerFunc.
+func (h *QueryParamHTTPConverter) SearchNodesHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/nodes", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &SearchNodesRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 2 || p[0] != "v1" || p[1] != "nodes" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/nodes"))
+			return
+		}
+
+		if v := r.URL.Query().Get("node.name"); v != "" {
+			if arg.Node == nil {
+				arg.Node = &Node{}
+			}
+			arg.Node.Name = v
+		}
+		if v := r.URL.Query().Get("node.parent.name"); v != "" {
+			if arg.Node == nil {
+				arg.Node = &Node{}
+			}
+			if arg.Node.Parent == nil {
+				arg.Node.Parent = &Node{}
+			}
+			arg.Node.Parent.Name = v
+		}
+		if v := r.URL.Query().Get("node.parent.parent.name"); v != "" {
+			if arg.Node == nil {
+				arg.Node = &Node{}
+			}
+			if arg.Node.Parent == nil {
+				arg.Node.Parent = &Node{}
+			}
+			if arg.Node.Parent.Parent == nil {
+				arg.Node.Parent.Parent = &Node{}
+			}
+			arg.Node.Parent.Parent.Name = v
+		}
+		if v := r.URL.Query().Get("node.parent.parent.parent.name"); v != "" {
+			if arg.Node == nil {
+				arg.Node = &Node{}
+			}
+			if arg.Node.Parent == nil {
+				arg.Node.Parent = &Node{}
+			}
+			if arg.Node.Parent.Parent == nil {
+				arg.Node.Parent.Parent = &Node{}
+			}
+			if arg.Node.Parent.Parent.Parent == nil {
+				arg.Node.Parent.Parent.Parent = &Node{}
+			}
+			arg.Node.Parent.Parent.Parent.Name = v
+		}
+		if v := r.URL.Query().Get("node.parent.parent.parent.parent.name"); v != "" {
+			if arg.Node == nil {
+				arg.Node = &Node{}
+			}
+			if arg.Node.Parent == nil {
+				arg.Node.Parent = &Node{}
+			}
+			if arg.Node.Parent.Parent == nil {
+				arg.Node.Parent.Parent = &Node{}
+			}
+			if arg.Node.Parent.Parent.Parent == nil {
+				arg.Node.Parent.Parent.Parent = &Node{}
+			}
+			if arg.Node.Parent.Parent.Parent.Parent == nil {
+				arg.Node.Parent.Parent.Parent.Parent = &Node{}
+			}
+			arg.Node.Parent.Parent.Parent.Parent.Name = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.QueryParam/SearchNodes",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.SearchNodes(c, req.(*SearchNodesRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*SearchNodesRequest)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.QueryParam/SearchNodes: interceptors have not return SearchNodesRequest"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
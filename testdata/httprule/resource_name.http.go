@@ -0,0 +1,526 @@
+// Code generated by protoc-gen-api. v1.0.0
+// source: httprule/resource_name.proto
+
+package httprulepb
+
+import (
+	context "context"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	proto "google.golang.org/protobuf/proto"
+	http "net/http"
+	url "net/url"
+	strings "strings"
+)
+
+// ResourceNameHTTPService is the server API for ResourceName service.
+type ResourceNameHTTPService interface {
+	GetTopic(context.Context, *GetTopicRequest) (*Topic, error)
+	ListSubscriptions(context.Context, *ListSubscriptionsRequest) (*Topic, error)
+	GetObject(context.Context, *GetObjectRequest) (*Topic, error)
+	CancelOperation(context.Context, *CancelOperationRequest) (*Topic, error)
+}
+
+// ResourceNameHTTPConverter has a function to convert ResourceNameHTTPService interface to http.HandlerFunc.
+type ResourceNameHTTPConverter struct {
+	srv        ResourceNameHTTPService
+	marshalers *runtime.Registry
+}
+
+// NewResourceNameHTTPConverter returns ResourceNameHTTPConverter.
+// The options configure the marshalers of the request and response bodies.
+func NewResourceNameHTTPConverter(srv ResourceNameHTTPService, opts ...runtime.Option) *ResourceNameHTTPConverter {
+	return &ResourceNameHTTPConverter{
+		srv:        srv,
+		marshalers: runtime.NewRegistry(opts...),
+	}
+}
+
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the ResourceNameHTTPConverter.
+func (h *ResourceNameHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *ResourceNameHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
+// GetTopic returns ResourceNameHTTPService interface's GetTopic converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) GetTopic(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetTopicRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.ResourceName/GetTopic",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetTopic(c, req.(*GetTopicRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Topic)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.ResourceName/GetTopic: interceptors have not return Topic"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetTopicWithName returns Service name, Method name and ResourceNameHTTPService interface's GetTopic converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) GetTopicWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "ResourceName", "GetTopic", h.GetTopic(cb, interceptors...)
+}
+
+// GetTopicHTTPRule returns HTTP method, path and ResourceNameHTTPService interface's GetTopic converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) GetTopicHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/{name=projects/*/topics/*}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetTopicRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 5 || p[0] != "v1" || p[1] != "projects" || p[3] != "topics" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/{name=projects/*/topics/*}"))
+			return
+		}
+
+		if v, err := url.PathUnescape(strings.NewReplacer("%2F", "%252F", "%2f", "%252f").Replace(strings.Join(p[1:5], "/"))); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "name", Err: err})
+			return
+		} else {
+			arg.Name = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.ResourceName/GetTopic",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetTopic(c, req.(*GetTopicRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Topic)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.ResourceName/GetTopic: interceptors have not return Topic"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// ListSubscriptions returns ResourceNameHTTPService interface's ListSubscriptions converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) ListSubscriptions(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListSubscriptionsRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.ResourceName/ListSubscriptions",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListSubscriptions(c, req.(*ListSubscriptionsRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Topic)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.ResourceName/ListSubscriptions: interceptors have not return Topic"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// ListSubscriptionsWithName returns Service name, Method name and ResourceNameHTTPService interface's ListSubscriptions converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) ListSubscriptionsWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "ResourceName", "ListSubscriptions", h.ListSubscriptions(cb, interceptors...)
+}
+
+// ListSubscriptionsHTTPRule returns HTTP method, path and ResourceNameHTTPService interface's ListSubscriptions converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) ListSubscriptionsHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/{topic=projects/*/topics/*}/subscriptions", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &ListSubscriptionsRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 6 || p[0] != "v1" || p[1] != "projects" || p[3] != "topics" || p[5] != "subscriptions" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/{topic=projects/*/topics/*}/subscriptions"))
+			return
+		}
+
+		if v, err := url.PathUnescape(strings.NewReplacer("%2F", "%252F", "%2f", "%252f").Replace(strings.Join(p[1:5], "/"))); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "topic", Err: err})
+			return
+		} else {
+			arg.Topic = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.ResourceName/ListSubscriptions",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.ListSubscriptions(c, req.(*ListSubscriptionsRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Topic)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.ResourceName/ListSubscriptions: interceptors have not return Topic"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetObject returns ResourceNameHTTPService interface's GetObject converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) GetObject(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetObjectRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.ResourceName/GetObject",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetObject(c, req.(*GetObjectRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Topic)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.ResourceName/GetObject: interceptors have not return Topic"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetObjectWithName returns Service name, Method name and ResourceNameHTTPService interface's GetObject converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) GetObjectWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "ResourceName", "GetObject", h.GetObject(cb, interceptors...)
+}
+
+// GetObjectHTTPRule returns HTTP method, path and ResourceNameHTTPService interface's GetObject converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) GetObjectHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/buckets/{bucket}/objects/{object=**}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetObjectRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) < 4 || p[0] != "v1" || p[1] != "buckets" || p[3] != "objects" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/buckets/{bucket}/objects/{object=**}"))
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "bucket", Err: err})
+			return
+		} else {
+			arg.Bucket = v
+		}
+		if v, err := url.PathUnescape(strings.NewReplacer("%2F", "%252F", "%2f", "%252f").Replace(strings.Join(p[4:], "/"))); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "object", Err: err})
+			return
+		} else {
+			arg.Object = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.ResourceName/GetObject",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetObject(c, req.(*GetObjectRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Topic)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.ResourceName/GetObject: interceptors have not return Topic"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// CancelOperation returns ResourceNameHTTPService interface's CancelOperation converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) CancelOperation(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &CancelOperationRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.ResourceName/CancelOperation",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.CancelOperation(c, req.(*CancelOperationRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Topic)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.ResourceName/CancelOperation: interceptors have not return Topic"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// CancelOperationWithName returns Service name, Method name and ResourceNameHTTPService interface's CancelOperation converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) CancelOperationWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "ResourceName", "CancelOperation", h.CancelOperation(cb, interceptors...)
+}
+
+// CancelOperationHTTPRule returns HTTP method, path and ResourceNameHTTPService interface's CancelOperation converted to http.HandlerFunc.
+func (h *ResourceNameHTTPConverter) CancelOperationHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodPost, "/v1/ops/{id}:cancel", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &CancelOperationRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		if !strings.HasSuffix(path, ":cancel") {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/ops/{id}:cancel"))
+			return
+		}
+		path = strings.TrimSuffix(path, ":cancel")
+		p := strings.Split(path, "/")
+		if len(p) != 3 || p[0] != "v1" || p[1] != "ops" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/ops/{id}:cancel"))
+			return
+		}
+
+		if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "id", Err: err})
+			return
+		} else {
+			arg.Id = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/httprule.ResourceName/CancelOperation",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.CancelOperation(c, req.(*CancelOperationRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Topic)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/httprule.ResourceName/CancelOperation: interceptors have not return Topic"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
@@ -0,0 +1,1505 @@
+// Code generated by protoc-gen-api. v1.0.0
+// source: inline/inline.proto
+
+package inlinepb
+
+import (
+	bytes "bytes"
+	context "context"
+	json "encoding/json"
+	errors "errors"
+	fmt "fmt"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protojson "google.golang.org/protobuf/encoding/protojson"
+	proto "google.golang.org/protobuf/proto"
+	io "io"
+	ioutil "io/ioutil"
+	mime "mime"
+	http "net/http"
+	url "net/url"
+	strconv "strconv"
+	strings "strings"
+)
+
+// LibraryHTTPService is the server API for Library service.
+type LibraryHTTPService interface {
+	GetBook(context.Context, *GetBookRequest) (*Book, error)
+	CreateBook(context.Context, *CreateBookRequest) (*Book, error)
+	GetBookTitle(context.Context, *GetBookRequest) (*Book, error)
+	GetBookCover(context.Context, *GetBookRequest) (*Book, error)
+}
+
+// LibraryHTTPConverter has a function to convert LibraryHTTPService interface to http.HandlerFunc.
+type LibraryHTTPConverter struct {
+	srv LibraryHTTPService
+}
+
+// NewLibraryHTTPConverter returns LibraryHTTPConverter.
+func NewLibraryHTTPConverter(srv LibraryHTTPService) *LibraryHTTPConverter {
+	return &LibraryHTTPConverter{
+		srv: srv,
+	}
+}
+
+// LibraryHTTPStatusError is an error the default callback responds with Status
+// instead of 500 Internal Server Error.
+type LibraryHTTPStatusError struct {
+	Status int
+	Err    error
+}
+
+func (e *LibraryHTTPStatusError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LibraryHTTPStatusError) Unwrap() error {
+	return e.Err
+}
+
+// contentTypes returns the media type of the request body of r and the media type of
+// the response, negotiated from the Accept header of r as described by RFC 7231
+// section 5.3.2. Media types are preferred by their quality value, then by their order
+// in the Accept header, then in the order of the request media type and application/json.
+// It returns a LibraryHTTPStatusError of 406 Not Acceptable when none is acceptable.
+func (h *LibraryHTTPConverter) contentTypes(r *http.Request) (contentType, accept string, err error) {
+	contentType, _, _ = mime.ParseMediaType(r.Header.Get("Content-Type"))
+	mediaTypes := []string{"application/json", "application/protobuf", "application/x-protobuf"}
+	for i, mediaType := range mediaTypes {
+		if mediaType == contentType {
+			copy(mediaTypes[1:i+1], mediaTypes[:i])
+			mediaTypes[0] = contentType
+		}
+	}
+
+	header := r.Header.Get("Accept")
+	if strings.TrimSpace(header) == "" {
+		header = "*/*"
+	}
+	var bestQ float64
+	var bestIndex int
+	for _, mediaType := range mediaTypes {
+		// The quality value is given by the most specific media range matching mediaType.
+		var q float64
+		var index, specificity int
+		for i, s := range strings.Split(header, ",") {
+			mediaRange, params, err := mime.ParseMediaType(s)
+			if err != nil {
+				continue
+			}
+			n := 0
+			switch {
+			case mediaRange == mediaType:
+				n = 3
+			case mediaRange != "*/*" && strings.HasSuffix(mediaRange, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*")):
+				n = 2
+			case mediaRange == "*/*":
+				n = 1
+			}
+			if n <= specificity {
+				continue
+			}
+			v := 1.0
+			if p, ok := params["q"]; ok {
+				if v, err = strconv.ParseFloat(p, 64); err != nil || v < 0 || v > 1 {
+					continue
+				}
+			}
+			q, index, specificity = v, i, n
+		}
+		if q > bestQ || (q == bestQ && q > 0 && index < bestIndex) {
+			accept, bestQ, bestIndex = mediaType, q, index
+		}
+	}
+	if bestQ == 0 {
+		return contentType, "", &LibraryHTTPStatusError{
+			Status: http.StatusNotAcceptable,
+			Err:    fmt.Errorf("Not Acceptable: %s", header),
+		}
+	}
+	return contentType, accept, nil
+}
+
+// GetBook returns LibraryHTTPService interface's GetBook converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBook(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+			if err != nil {
+				s, ok := status.FromError(err)
+				var se interface{ GRPCStatus() *status.Status }
+				if !ok && errors.As(err, &se) {
+					// A wrapped status is found as well.
+					s = se.GRPCStatus()
+				}
+				code := http.StatusInternalServerError
+				switch s.Code() {
+				case codes.OK:
+					code = http.StatusOK
+				case codes.Canceled:
+					code = 499
+				case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+					code = http.StatusBadRequest
+				case codes.DeadlineExceeded:
+					code = http.StatusGatewayTimeout
+				case codes.NotFound:
+					code = http.StatusNotFound
+				case codes.AlreadyExists, codes.Aborted:
+					code = http.StatusConflict
+				case codes.PermissionDenied:
+					code = http.StatusForbidden
+				case codes.Unauthenticated:
+					code = http.StatusUnauthorized
+				case codes.ResourceExhausted:
+					code = http.StatusTooManyRequests
+				case codes.Unimplemented:
+					code = http.StatusNotImplemented
+				case codes.Unavailable:
+					code = http.StatusServiceUnavailable
+				}
+				var httpErr *LibraryHTTPStatusError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Status
+				}
+				p := s.Proto()
+
+				accept := "application/json"
+				if _, a, err := h.contentTypes(r); err == nil {
+					accept = a
+				}
+				var buf []byte
+				switch accept {
+				case "application/protobuf", "application/x-protobuf":
+					buf, err = proto.Marshal(p)
+				default:
+					buf, err = protojson.Marshal(p)
+				}
+				if err != nil {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set("Content-Type", accept)
+				w.WriteHeader(code)
+				_, _ = w.Write(buf)
+			}
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		contentType, accept, err := h.contentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetBookRequest{}
+		if r.Method != http.MethodGet {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+				return
+			}
+
+			switch contentType {
+			case "application/protobuf", "application/x-protobuf":
+				if err := proto.Unmarshal(body, arg); err != nil {
+					cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+					return
+				}
+			case "application/json":
+				if err := protojson.Unmarshal(body, arg); err != nil {
+					cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+					return
+				}
+			default:
+				cb(ctx, w, r, nil, nil, &LibraryHTTPStatusError{
+					Status: http.StatusUnsupportedMediaType,
+					Err:    fmt.Errorf("Unsupported Content-Type: %s", contentType),
+				})
+				return
+			}
+		}
+
+		n := len(interceptors)
+		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
+				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
+					return currentInter(currentCtx, currentReq, info, currentHandler)
+				}
+			}
+
+			chainedHandler := handler
+			for i := n - 1; i >= 0; i-- {
+				chainedHandler = chainer(interceptors[i], chainedHandler)
+			}
+			return chainedHandler(ctx, arg)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/inline.Library/GetBook",
+		}
+
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetBook(c, req.(*GetBookRequest))
+		}
+
+		iret, err := chained(ctx, arg, info, handler)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Book)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/inline.Library/GetBook: interceptors have not return Book"))
+			return
+		}
+
+		switch accept {
+		case "application/protobuf", "application/x-protobuf":
+			buf, err := proto.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		case "application/json":
+			buf, err := protojson.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetBookWithName returns Service name, Method name and LibraryHTTPService interface's GetBook converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBookWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Library", "GetBook", h.GetBook(cb, interceptors...)
+}
+
+// GetBookHTTPRule returns HTTP method, path and LibraryHTTPService interface's GetBook converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBookHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+			if err != nil {
+				s, ok := status.FromError(err)
+				var se interface{ GRPCStatus() *status.Status }
+				if !ok && errors.As(err, &se) {
+					// A wrapped status is found as well.
+					s = se.GRPCStatus()
+				}
+				code := http.StatusInternalServerError
+				switch s.Code() {
+				case codes.OK:
+					code = http.StatusOK
+				case codes.Canceled:
+					code = 499
+				case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+					code = http.StatusBadRequest
+				case codes.DeadlineExceeded:
+					code = http.StatusGatewayTimeout
+				case codes.NotFound:
+					code = http.StatusNotFound
+				case codes.AlreadyExists, codes.Aborted:
+					code = http.StatusConflict
+				case codes.PermissionDenied:
+					code = http.StatusForbidden
+				case codes.Unauthenticated:
+					code = http.StatusUnauthorized
+				case codes.ResourceExhausted:
+					code = http.StatusTooManyRequests
+				case codes.Unimplemented:
+					code = http.StatusNotImplemented
+				case codes.Unavailable:
+					code = http.StatusServiceUnavailable
+				}
+				var httpErr *LibraryHTTPStatusError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Status
+				}
+				p := s.Proto()
+
+				accept := "application/json"
+				if _, a, err := h.contentTypes(r); err == nil {
+					accept = a
+				}
+				var buf []byte
+				switch accept {
+				case "application/protobuf", "application/x-protobuf":
+					buf, err = proto.Marshal(p)
+				default:
+					buf, err = protojson.Marshal(p)
+				}
+				if err != nil {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set("Content-Type", accept)
+				w.WriteHeader(code)
+				_, _ = w.Write(buf)
+			}
+		}
+	}
+	return http.MethodGet, "/v1/shelves/{shelf}/books/{book_id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		_, accept, err := h.contentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetBookRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 5 || p[0] != "v1" || p[1] != "shelves" || p[3] != "books" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/shelves/{shelf}/books/{book_id}"))
+			return
+		}
+
+		if v := r.URL.Query().Get("full"); v != "" {
+			c, err := strconv.ParseBool(v)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid query parameter %q: %v", "full", err))
+				return
+			}
+			arg.Full = c
+		}
+
+		if v, err := url.PathUnescape(p[4]); err != nil {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "book_id", err))
+			return
+		} else if v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "book_id", err))
+				return
+			}
+			arg.BookId = c
+		}
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "shelf", err))
+			return
+		} else {
+			arg.Shelf = v
+		}
+
+		n := len(interceptors)
+		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
+				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
+					return currentInter(currentCtx, currentReq, info, currentHandler)
+				}
+			}
+
+			chainedHandler := handler
+			for i := n - 1; i >= 0; i-- {
+				chainedHandler = chainer(interceptors[i], chainedHandler)
+			}
+			return chainedHandler(ctx, arg)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/inline.Library/GetBook",
+		}
+
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetBook(c, req.(*GetBookRequest))
+		}
+
+		iret, err := chained(ctx, arg, info, handler)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Book)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/inline.Library/GetBook: interceptors have not return Book"))
+			return
+		}
+
+		switch accept {
+		case "application/protobuf", "application/x-protobuf":
+			buf, err := proto.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		case "application/json":
+			buf, err := protojson.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// CreateBook returns LibraryHTTPService interface's CreateBook converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) CreateBook(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+			if err != nil {
+				s, ok := status.FromError(err)
+				var se interface{ GRPCStatus() *status.Status }
+				if !ok && errors.As(err, &se) {
+					// A wrapped status is found as well.
+					s = se.GRPCStatus()
+				}
+				code := http.StatusInternalServerError
+				switch s.Code() {
+				case codes.OK:
+					code = http.StatusOK
+				case codes.Canceled:
+					code = 499
+				case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+					code = http.StatusBadRequest
+				case codes.DeadlineExceeded:
+					code = http.StatusGatewayTimeout
+				case codes.NotFound:
+					code = http.StatusNotFound
+				case codes.AlreadyExists, codes.Aborted:
+					code = http.StatusConflict
+				case codes.PermissionDenied:
+					code = http.StatusForbidden
+				case codes.Unauthenticated:
+					code = http.StatusUnauthorized
+				case codes.ResourceExhausted:
+					code = http.StatusTooManyRequests
+				case codes.Unimplemented:
+					code = http.StatusNotImplemented
+				case codes.Unavailable:
+					code = http.StatusServiceUnavailable
+				}
+				var httpErr *LibraryHTTPStatusError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Status
+				}
+				p := s.Proto()
+
+				accept := "application/json"
+				if _, a, err := h.contentTypes(r); err == nil {
+					accept = a
+				}
+				var buf []byte
+				switch accept {
+				case "application/protobuf", "application/x-protobuf":
+					buf, err = proto.Marshal(p)
+				default:
+					buf, err = protojson.Marshal(p)
+				}
+				if err != nil {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set("Content-Type", accept)
+				w.WriteHeader(code)
+				_, _ = w.Write(buf)
+			}
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		contentType, accept, err := h.contentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &CreateBookRequest{}
+		if r.Method != http.MethodGet {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+				return
+			}
+
+			switch contentType {
+			case "application/protobuf", "application/x-protobuf":
+				if err := proto.Unmarshal(body, arg); err != nil {
+					cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+					return
+				}
+			case "application/json":
+				if err := protojson.Unmarshal(body, arg); err != nil {
+					cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+					return
+				}
+			default:
+				cb(ctx, w, r, nil, nil, &LibraryHTTPStatusError{
+					Status: http.StatusUnsupportedMediaType,
+					Err:    fmt.Errorf("Unsupported Content-Type: %s", contentType),
+				})
+				return
+			}
+		}
+
+		n := len(interceptors)
+		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
+				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
+					return currentInter(currentCtx, currentReq, info, currentHandler)
+				}
+			}
+
+			chainedHandler := handler
+			for i := n - 1; i >= 0; i-- {
+				chainedHandler = chainer(interceptors[i], chainedHandler)
+			}
+			return chainedHandler(ctx, arg)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/inline.Library/CreateBook",
+		}
+
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.CreateBook(c, req.(*CreateBookRequest))
+		}
+
+		iret, err := chained(ctx, arg, info, handler)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Book)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/inline.Library/CreateBook: interceptors have not return Book"))
+			return
+		}
+
+		switch accept {
+		case "application/protobuf", "application/x-protobuf":
+			buf, err := proto.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		case "application/json":
+			buf, err := protojson.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// CreateBookWithName returns Service name, Method name and LibraryHTTPService interface's CreateBook converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) CreateBookWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Library", "CreateBook", h.CreateBook(cb, interceptors...)
+}
+
+// CreateBookHTTPRule returns HTTP method, path and LibraryHTTPService interface's CreateBook converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) CreateBookHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+			if err != nil {
+				s, ok := status.FromError(err)
+				var se interface{ GRPCStatus() *status.Status }
+				if !ok && errors.As(err, &se) {
+					// A wrapped status is found as well.
+					s = se.GRPCStatus()
+				}
+				code := http.StatusInternalServerError
+				switch s.Code() {
+				case codes.OK:
+					code = http.StatusOK
+				case codes.Canceled:
+					code = 499
+				case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+					code = http.StatusBadRequest
+				case codes.DeadlineExceeded:
+					code = http.StatusGatewayTimeout
+				case codes.NotFound:
+					code = http.StatusNotFound
+				case codes.AlreadyExists, codes.Aborted:
+					code = http.StatusConflict
+				case codes.PermissionDenied:
+					code = http.StatusForbidden
+				case codes.Unauthenticated:
+					code = http.StatusUnauthorized
+				case codes.ResourceExhausted:
+					code = http.StatusTooManyRequests
+				case codes.Unimplemented:
+					code = http.StatusNotImplemented
+				case codes.Unavailable:
+					code = http.StatusServiceUnavailable
+				}
+				var httpErr *LibraryHTTPStatusError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Status
+				}
+				p := s.Proto()
+
+				accept := "application/json"
+				if _, a, err := h.contentTypes(r); err == nil {
+					accept = a
+				}
+				var buf []byte
+				switch accept {
+				case "application/protobuf", "application/x-protobuf":
+					buf, err = proto.Marshal(p)
+				default:
+					buf, err = protojson.Marshal(p)
+				}
+				if err != nil {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set("Content-Type", accept)
+				w.WriteHeader(code)
+				_, _ = w.Write(buf)
+			}
+		}
+	}
+	return http.MethodPost, "/v1/shelves/{shelf}/books", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		contentType, accept, err := h.contentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &CreateBookRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 4 || p[0] != "v1" || p[1] != "shelves" || p[3] != "books" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/shelves/{shelf}/books"))
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid body field %q: %v", "book", err))
+			return
+		}
+
+		arg.Book = &Book{}
+		switch contentType {
+		case "application/protobuf", "application/x-protobuf":
+			if err := proto.Unmarshal(body, arg.Book); err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid body field %q: %v", "book", err))
+				return
+			}
+		case "application/json":
+			if err := protojson.Unmarshal(body, arg.Book); err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid body field %q: %v", "book", err))
+				return
+			}
+		default:
+			cb(ctx, w, r, nil, nil, &LibraryHTTPStatusError{
+				Status: http.StatusUnsupportedMediaType,
+				Err:    fmt.Errorf("Unsupported Content-Type: %s", contentType),
+			})
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "shelf", err))
+			return
+		} else {
+			arg.Shelf = v
+		}
+
+		n := len(interceptors)
+		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
+				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
+					return currentInter(currentCtx, currentReq, info, currentHandler)
+				}
+			}
+
+			chainedHandler := handler
+			for i := n - 1; i >= 0; i-- {
+				chainedHandler = chainer(interceptors[i], chainedHandler)
+			}
+			return chainedHandler(ctx, arg)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/inline.Library/CreateBook",
+		}
+
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.CreateBook(c, req.(*CreateBookRequest))
+		}
+
+		iret, err := chained(ctx, arg, info, handler)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Book)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/inline.Library/CreateBook: interceptors have not return Book"))
+			return
+		}
+
+		switch accept {
+		case "application/protobuf", "application/x-protobuf":
+			buf, err := proto.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		case "application/json":
+			buf, err := protojson.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetBookTitle returns LibraryHTTPService interface's GetBookTitle converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBookTitle(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+			if err != nil {
+				s, ok := status.FromError(err)
+				var se interface{ GRPCStatus() *status.Status }
+				if !ok && errors.As(err, &se) {
+					// A wrapped status is found as well.
+					s = se.GRPCStatus()
+				}
+				code := http.StatusInternalServerError
+				switch s.Code() {
+				case codes.OK:
+					code = http.StatusOK
+				case codes.Canceled:
+					code = 499
+				case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+					code = http.StatusBadRequest
+				case codes.DeadlineExceeded:
+					code = http.StatusGatewayTimeout
+				case codes.NotFound:
+					code = http.StatusNotFound
+				case codes.AlreadyExists, codes.Aborted:
+					code = http.StatusConflict
+				case codes.PermissionDenied:
+					code = http.StatusForbidden
+				case codes.Unauthenticated:
+					code = http.StatusUnauthorized
+				case codes.ResourceExhausted:
+					code = http.StatusTooManyRequests
+				case codes.Unimplemented:
+					code = http.StatusNotImplemented
+				case codes.Unavailable:
+					code = http.StatusServiceUnavailable
+				}
+				var httpErr *LibraryHTTPStatusError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Status
+				}
+				p := s.Proto()
+
+				accept := "application/json"
+				if _, a, err := h.contentTypes(r); err == nil {
+					accept = a
+				}
+				var buf []byte
+				switch accept {
+				case "application/protobuf", "application/x-protobuf":
+					buf, err = proto.Marshal(p)
+				default:
+					buf, err = protojson.Marshal(p)
+				}
+				if err != nil {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set("Content-Type", accept)
+				w.WriteHeader(code)
+				_, _ = w.Write(buf)
+			}
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		contentType, accept, err := h.contentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetBookRequest{}
+		if r.Method != http.MethodGet {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+				return
+			}
+
+			switch contentType {
+			case "application/protobuf", "application/x-protobuf":
+				if err := proto.Unmarshal(body, arg); err != nil {
+					cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+					return
+				}
+			case "application/json":
+				if err := protojson.Unmarshal(body, arg); err != nil {
+					cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+					return
+				}
+			default:
+				cb(ctx, w, r, nil, nil, &LibraryHTTPStatusError{
+					Status: http.StatusUnsupportedMediaType,
+					Err:    fmt.Errorf("Unsupported Content-Type: %s", contentType),
+				})
+				return
+			}
+		}
+
+		n := len(interceptors)
+		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
+				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
+					return currentInter(currentCtx, currentReq, info, currentHandler)
+				}
+			}
+
+			chainedHandler := handler
+			for i := n - 1; i >= 0; i-- {
+				chainedHandler = chainer(interceptors[i], chainedHandler)
+			}
+			return chainedHandler(ctx, arg)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/inline.Library/GetBookTitle",
+		}
+
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetBookTitle(c, req.(*GetBookRequest))
+		}
+
+		iret, err := chained(ctx, arg, info, handler)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Book)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/inline.Library/GetBookTitle: interceptors have not return Book"))
+			return
+		}
+
+		switch accept {
+		case "application/protobuf", "application/x-protobuf":
+			buf, err := proto.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		case "application/json":
+			buf, err := protojson.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetBookTitleWithName returns Service name, Method name and LibraryHTTPService interface's GetBookTitle converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBookTitleWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Library", "GetBookTitle", h.GetBookTitle(cb, interceptors...)
+}
+
+// GetBookTitleHTTPRule returns HTTP method, path and LibraryHTTPService interface's GetBookTitle converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBookTitleHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+			if err != nil {
+				s, ok := status.FromError(err)
+				var se interface{ GRPCStatus() *status.Status }
+				if !ok && errors.As(err, &se) {
+					// A wrapped status is found as well.
+					s = se.GRPCStatus()
+				}
+				code := http.StatusInternalServerError
+				switch s.Code() {
+				case codes.OK:
+					code = http.StatusOK
+				case codes.Canceled:
+					code = 499
+				case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+					code = http.StatusBadRequest
+				case codes.DeadlineExceeded:
+					code = http.StatusGatewayTimeout
+				case codes.NotFound:
+					code = http.StatusNotFound
+				case codes.AlreadyExists, codes.Aborted:
+					code = http.StatusConflict
+				case codes.PermissionDenied:
+					code = http.StatusForbidden
+				case codes.Unauthenticated:
+					code = http.StatusUnauthorized
+				case codes.ResourceExhausted:
+					code = http.StatusTooManyRequests
+				case codes.Unimplemented:
+					code = http.StatusNotImplemented
+				case codes.Unavailable:
+					code = http.StatusServiceUnavailable
+				}
+				var httpErr *LibraryHTTPStatusError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Status
+				}
+				p := s.Proto()
+
+				accept := "application/json"
+				if _, a, err := h.contentTypes(r); err == nil {
+					accept = a
+				}
+				var buf []byte
+				switch accept {
+				case "application/protobuf", "application/x-protobuf":
+					buf, err = proto.Marshal(p)
+				default:
+					buf, err = protojson.Marshal(p)
+				}
+				if err != nil {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set("Content-Type", accept)
+				w.WriteHeader(code)
+				_, _ = w.Write(buf)
+			}
+		}
+	}
+	return http.MethodGet, "/v1/shelves/{shelf}/books/{book_id}/title", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		_, accept, err := h.contentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetBookRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 6 || p[0] != "v1" || p[1] != "shelves" || p[3] != "books" || p[5] != "title" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/shelves/{shelf}/books/{book_id}/title"))
+			return
+		}
+
+		if v := r.URL.Query().Get("full"); v != "" {
+			c, err := strconv.ParseBool(v)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid query parameter %q: %v", "full", err))
+				return
+			}
+			arg.Full = c
+		}
+
+		if v, err := url.PathUnescape(p[4]); err != nil {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "book_id", err))
+			return
+		} else if v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "book_id", err))
+				return
+			}
+			arg.BookId = c
+		}
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "shelf", err))
+			return
+		} else {
+			arg.Shelf = v
+		}
+
+		n := len(interceptors)
+		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
+				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
+					return currentInter(currentCtx, currentReq, info, currentHandler)
+				}
+			}
+
+			chainedHandler := handler
+			for i := n - 1; i >= 0; i-- {
+				chainedHandler = chainer(interceptors[i], chainedHandler)
+			}
+			return chainedHandler(ctx, arg)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/inline.Library/GetBookTitle",
+		}
+
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetBookTitle(c, req.(*GetBookRequest))
+		}
+
+		iret, err := chained(ctx, arg, info, handler)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Book)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/inline.Library/GetBookTitle: interceptors have not return Book"))
+			return
+		}
+
+		res := ret.ProtoReflect().New()
+		if fd := res.Descriptor().Fields().ByName("title"); ret.ProtoReflect().Has(fd) {
+			res.Set(fd, ret.ProtoReflect().Get(fd))
+		}
+
+		switch accept {
+		case "application/protobuf", "application/x-protobuf":
+			buf, err := proto.Marshal(res.Interface())
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		case "application/json":
+			buf, err := (protojson.MarshalOptions{EmitUnpopulated: true}).Marshal(res.Interface())
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(buf, &fields); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			buf, ok := fields["title"]
+			if !ok {
+				buf = []byte("null")
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetBookCover returns LibraryHTTPService interface's GetBookCover converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBookCover(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+			if err != nil {
+				s, ok := status.FromError(err)
+				var se interface{ GRPCStatus() *status.Status }
+				if !ok && errors.As(err, &se) {
+					// A wrapped status is found as well.
+					s = se.GRPCStatus()
+				}
+				code := http.StatusInternalServerError
+				switch s.Code() {
+				case codes.OK:
+					code = http.StatusOK
+				case codes.Canceled:
+					code = 499
+				case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+					code = http.StatusBadRequest
+				case codes.DeadlineExceeded:
+					code = http.StatusGatewayTimeout
+				case codes.NotFound:
+					code = http.StatusNotFound
+				case codes.AlreadyExists, codes.Aborted:
+					code = http.StatusConflict
+				case codes.PermissionDenied:
+					code = http.StatusForbidden
+				case codes.Unauthenticated:
+					code = http.StatusUnauthorized
+				case codes.ResourceExhausted:
+					code = http.StatusTooManyRequests
+				case codes.Unimplemented:
+					code = http.StatusNotImplemented
+				case codes.Unavailable:
+					code = http.StatusServiceUnavailable
+				}
+				var httpErr *LibraryHTTPStatusError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Status
+				}
+				p := s.Proto()
+
+				accept := "application/json"
+				if _, a, err := h.contentTypes(r); err == nil {
+					accept = a
+				}
+				var buf []byte
+				switch accept {
+				case "application/protobuf", "application/x-protobuf":
+					buf, err = proto.Marshal(p)
+				default:
+					buf, err = protojson.Marshal(p)
+				}
+				if err != nil {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set("Content-Type", accept)
+				w.WriteHeader(code)
+				_, _ = w.Write(buf)
+			}
+		}
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		contentType, accept, err := h.contentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetBookRequest{}
+		if r.Method != http.MethodGet {
+			body, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+				return
+			}
+
+			switch contentType {
+			case "application/protobuf", "application/x-protobuf":
+				if err := proto.Unmarshal(body, arg); err != nil {
+					cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+					return
+				}
+			case "application/json":
+				if err := protojson.Unmarshal(body, arg); err != nil {
+					cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid request body: %v", err))
+					return
+				}
+			default:
+				cb(ctx, w, r, nil, nil, &LibraryHTTPStatusError{
+					Status: http.StatusUnsupportedMediaType,
+					Err:    fmt.Errorf("Unsupported Content-Type: %s", contentType),
+				})
+				return
+			}
+		}
+
+		n := len(interceptors)
+		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
+				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
+					return currentInter(currentCtx, currentReq, info, currentHandler)
+				}
+			}
+
+			chainedHandler := handler
+			for i := n - 1; i >= 0; i-- {
+				chainedHandler = chainer(interceptors[i], chainedHandler)
+			}
+			return chainedHandler(ctx, arg)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/inline.Library/GetBookCover",
+		}
+
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetBookCover(c, req.(*GetBookRequest))
+		}
+
+		iret, err := chained(ctx, arg, info, handler)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Book)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/inline.Library/GetBookCover: interceptors have not return Book"))
+			return
+		}
+
+		switch accept {
+		case "application/protobuf", "application/x-protobuf":
+			buf, err := proto.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		case "application/json":
+			buf, err := protojson.Marshal(ret)
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetBookCoverWithName returns Service name, Method name and LibraryHTTPService interface's GetBookCover converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBookCoverWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Library", "GetBookCover", h.GetBookCover(cb, interceptors...)
+}
+
+// GetBookCoverHTTPRule returns HTTP method, path and LibraryHTTPService interface's GetBookCover converted to http.HandlerFunc.
+func (h *LibraryHTTPConverter) GetBookCoverHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
+			if err != nil {
+				s, ok := status.FromError(err)
+				var se interface{ GRPCStatus() *status.Status }
+				if !ok && errors.As(err, &se) {
+					// A wrapped status is found as well.
+					s = se.GRPCStatus()
+				}
+				code := http.StatusInternalServerError
+				switch s.Code() {
+				case codes.OK:
+					code = http.StatusOK
+				case codes.Canceled:
+					code = 499
+				case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+					code = http.StatusBadRequest
+				case codes.DeadlineExceeded:
+					code = http.StatusGatewayTimeout
+				case codes.NotFound:
+					code = http.StatusNotFound
+				case codes.AlreadyExists, codes.Aborted:
+					code = http.StatusConflict
+				case codes.PermissionDenied:
+					code = http.StatusForbidden
+				case codes.Unauthenticated:
+					code = http.StatusUnauthorized
+				case codes.ResourceExhausted:
+					code = http.StatusTooManyRequests
+				case codes.Unimplemented:
+					code = http.StatusNotImplemented
+				case codes.Unavailable:
+					code = http.StatusServiceUnavailable
+				}
+				var httpErr *LibraryHTTPStatusError
+				if errors.As(err, &httpErr) {
+					code = httpErr.Status
+				}
+				p := s.Proto()
+
+				accept := "application/json"
+				if _, a, err := h.contentTypes(r); err == nil {
+					accept = a
+				}
+				var buf []byte
+				switch accept {
+				case "application/protobuf", "application/x-protobuf":
+					buf, err = proto.Marshal(p)
+				default:
+					buf, err = protojson.Marshal(p)
+				}
+				if err != nil {
+					w.WriteHeader(code)
+					return
+				}
+				w.Header().Set("Content-Type", accept)
+				w.WriteHeader(code)
+				_, _ = w.Write(buf)
+			}
+		}
+	}
+	return http.MethodGet, "/v1/shelves/{shelf}/books/{book_id}/cover", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		_, accept, err := h.contentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetBookRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 6 || p[0] != "v1" || p[1] != "shelves" || p[3] != "books" || p[5] != "cover" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/shelves/{shelf}/books/{book_id}/cover"))
+			return
+		}
+
+		if v := r.URL.Query().Get("full"); v != "" {
+			c, err := strconv.ParseBool(v)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid query parameter %q: %v", "full", err))
+				return
+			}
+			arg.Full = c
+		}
+
+		if v, err := url.PathUnescape(p[4]); err != nil {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "book_id", err))
+			return
+		} else if v != "" {
+			c, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "book_id", err))
+				return
+			}
+			arg.BookId = c
+		}
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.InvalidArgument, "invalid path parameter %q: %v", "shelf", err))
+			return
+		} else {
+			arg.Shelf = v
+		}
+
+		n := len(interceptors)
+		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
+				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
+					return currentInter(currentCtx, currentReq, info, currentHandler)
+				}
+			}
+
+			chainedHandler := handler
+			for i := n - 1; i >= 0; i-- {
+				chainedHandler = chainer(interceptors[i], chainedHandler)
+			}
+			return chainedHandler(ctx, arg)
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/inline.Library/GetBookCover",
+		}
+
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetBookCover(c, req.(*GetBookRequest))
+		}
+
+		iret, err := chained(ctx, arg, info, handler)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Book)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/inline.Library/GetBookCover: interceptors have not return Book"))
+			return
+		}
+
+		switch accept {
+		case "application/protobuf", "application/x-protobuf":
+			buf, err := proto.Marshal(ret.GetCover())
+			if err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		case "application/json":
+			buf := []byte("null")
+			if ret.GetCover() != nil {
+				var err error
+				if buf, err = protojson.Marshal(ret.GetCover()); err != nil {
+					cb(ctx, w, r, arg, ret, err)
+					return
+				}
+			}
+			w.Header().Set("Content-Type", accept)
+			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
+				cb(ctx, w, r, arg, ret, err)
+				return
+			}
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
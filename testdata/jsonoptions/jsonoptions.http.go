@@ -0,0 +1,288 @@
+// Code generated by protoc-gen-api. v1.0.0
+// source: jsonoptions/jsonoptions.proto
+
+package jsonoptionspb
+
+import (
+	context "context"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protojson "google.golang.org/protobuf/encoding/protojson"
+	proto "google.golang.org/protobuf/proto"
+	http "net/http"
+	url "net/url"
+	strings "strings"
+)
+
+// SettingsHTTPService is the server API for Settings service.
+type SettingsHTTPService interface {
+	GetSetting(context.Context, *GetSettingRequest) (*Setting, error)
+	UpdateSetting(context.Context, *UpdateSettingRequest) (*Setting, error)
+}
+
+// SettingsHTTPConverter has a function to convert SettingsHTTPService interface to http.HandlerFunc.
+type SettingsHTTPConverter struct {
+	srv        SettingsHTTPService
+	marshalers *runtime.Registry
+}
+
+// NewSettingsHTTPConverter returns SettingsHTTPConverter.
+// The options configure the marshalers of the request and response bodies.
+func NewSettingsHTTPConverter(srv SettingsHTTPService, opts ...runtime.Option) *SettingsHTTPConverter {
+	return &SettingsHTTPConverter{
+		srv: srv,
+		marshalers: runtime.NewRegistry(append([]runtime.Option{
+			runtime.WithMarshalOptions(protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}),
+			runtime.WithUnmarshalOptions(protojson.UnmarshalOptions{DiscardUnknown: true}),
+		}, opts...)...),
+	}
+}
+
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the SettingsHTTPConverter.
+func (h *SettingsHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *SettingsHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
+// GetSetting returns SettingsHTTPService interface's GetSetting converted to http.HandlerFunc.
+func (h *SettingsHTTPConverter) GetSetting(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetSettingRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/jsonoptions.Settings/GetSetting",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetSetting(c, req.(*GetSettingRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Setting)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/jsonoptions.Settings/GetSetting: interceptors have not return Setting"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// GetSettingWithName returns Service name, Method name and SettingsHTTPService interface's GetSetting converted to http.HandlerFunc.
+func (h *SettingsHTTPConverter) GetSettingWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Settings", "GetSetting", h.GetSetting(cb, interceptors...)
+}
+
+// GetSettingHTTPRule returns HTTP method, path and SettingsHTTPService interface's GetSetting converted to http.HandlerFunc.
+func (h *SettingsHTTPConverter) GetSettingHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodGet, "/v1/settings/{setting_name}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		_, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &GetSettingRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 3 || p[0] != "v1" || p[1] != "settings" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/settings/{setting_name}"))
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "setting_name", Err: err})
+			return
+		} else {
+			arg.SettingName = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/jsonoptions.Settings/GetSetting",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.GetSetting(c, req.(*GetSettingRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Setting)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/jsonoptions.Settings/GetSetting: interceptors have not return Setting"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// UpdateSetting returns SettingsHTTPService interface's UpdateSetting converted to http.HandlerFunc.
+func (h *SettingsHTTPConverter) UpdateSetting(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &UpdateSettingRequest{}
+		if r.Method != http.MethodGet {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
+				cb(ctx, w, r, nil, nil, err)
+				return
+			}
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/jsonoptions.Settings/UpdateSetting",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.UpdateSetting(c, req.(*UpdateSettingRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Setting)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/jsonoptions.Settings/UpdateSetting: interceptors have not return Setting"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
+
+// UpdateSettingWithName returns Service name, Method name and SettingsHTTPService interface's UpdateSetting converted to http.HandlerFunc.
+func (h *SettingsHTTPConverter) UpdateSettingWithName(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	return "Settings", "UpdateSetting", h.UpdateSetting(cb, interceptors...)
+}
+
+// UpdateSettingHTTPRule returns HTTP method, path and SettingsHTTPService interface's UpdateSetting converted to http.HandlerFunc.
+func (h *SettingsHTTPConverter) UpdateSettingHTTPRule(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) (string, string, http.HandlerFunc) {
+	if cb == nil {
+		cb = runtime.DefaultCallback
+	}
+	return http.MethodPatch, "/v1/settings/{setting.setting_name}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
+
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		arg := &UpdateSettingRequest{}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), "/")
+		p := strings.Split(path, "/")
+		if len(p) != 3 || p[0] != "v1" || p[1] != "settings" {
+			cb(ctx, w, r, nil, nil, status.Errorf(codes.NotFound, "path %q does not match %q", r.URL.Path, "/v1/settings/{setting.setting_name}"))
+			return
+		}
+
+		if err := h.marshalers.UnmarshalRequest(r, contentType, arg, "setting"); err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
+		}
+
+		if v, err := url.PathUnescape(p[2]); err != nil {
+			cb(ctx, w, r, nil, nil, &runtime.BindingError{Source: runtime.SourcePath, Field: "setting.setting_name", Err: err})
+			return
+		} else {
+			if arg.Setting == nil {
+				arg.Setting = &Setting{}
+			}
+			arg.Setting.SettingName = v
+		}
+
+		info := &grpc.UnaryServerInfo{
+			Server:     h.srv,
+			FullMethod: "/jsonoptions.Settings/UpdateSetting",
+		}
+		handler := func(c context.Context, req interface{}) (interface{}, error) {
+			return h.srv.UpdateSetting(c, req.(*UpdateSettingRequest))
+		}
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
+		if err != nil {
+			cb(ctx, w, r, arg, nil, err)
+			return
+		}
+
+		ret, ok := iret.(*Setting)
+		if !ok {
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/jsonoptions.Settings/UpdateSetting: interceptors have not return Setting"))
+			return
+		}
+
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
+			cb(ctx, w, r, arg, ret, err)
+			return
+		}
+		cb(ctx, w, r, arg, ret, nil)
+	})
+}
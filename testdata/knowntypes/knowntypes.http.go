@@ -4,13 +4,11 @@
 package knowntypespb
 
 import (
-	bytes "bytes"
 	context "context"
-	fmt "fmt"
+	runtime "github.com/weblfe/protoc-gen-api/pkg/runtime"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
-	protojson "google.golang.org/protobuf/encoding/protojson"
 	proto "google.golang.org/protobuf/proto"
 	anypb "google.golang.org/protobuf/types/known/anypb"
 	apipb "google.golang.org/protobuf/types/known/apipb"
@@ -18,14 +16,11 @@ import (
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	sourcecontextpb "google.golang.org/protobuf/types/known/sourcecontextpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	typepb "google.golang.org/protobuf/types/known/typepb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
-	io "io"
-	ioutil "io/ioutil"
-	mime "mime"
 	http "net/http"
-	strings "strings"
 )
 
 // KnownTypesServiceHTTPService is the server API for KnownTypesService service.
@@ -36,7 +31,7 @@ type KnownTypesServiceHTTPService interface {
 	Empty(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	FieldMask(context.Context, *fieldmaskpb.FieldMask) (*fieldmaskpb.FieldMask, error)
 	SourceContext(context.Context, *sourcecontextpb.SourceContext) (*sourcecontextpb.SourceContext, error)
-	Struct(context.Context, *status.Struct) (*status.Struct, error)
+	Struct(context.Context, *structpb.Struct) (*structpb.Struct, error)
 	Timestamp(context.Context, *timestamppb.Timestamp) (*timestamppb.Timestamp, error)
 	Type(context.Context, *typepb.Type) (*typepb.Type, error)
 	Wrappers(context.Context, *wrapperspb.BoolValue) (*wrapperspb.BoolValue, error)
@@ -44,114 +39,59 @@ type KnownTypesServiceHTTPService interface {
 
 // KnownTypesServiceHTTPConverter has a function to convert KnownTypesServiceHTTPService interface to http.HandlerFunc.
 type KnownTypesServiceHTTPConverter struct {
-	srv KnownTypesServiceHTTPService
+	srv        KnownTypesServiceHTTPService
+	marshalers *runtime.Registry
 }
 
 // NewKnownTypesServiceHTTPConverter returns KnownTypesServiceHTTPConverter.
-func NewKnownTypesServiceHTTPConverter(srv KnownTypesServiceHTTPService) *KnownTypesServiceHTTPConverter {
+// The options configure the marshalers of the request and response bodies.
+func NewKnownTypesServiceHTTPConverter(srv KnownTypesServiceHTTPService, opts ...runtime.Option) *KnownTypesServiceHTTPConverter {
 	return &KnownTypesServiceHTTPConverter{
-		srv: srv,
+		srv:        srv,
+		marshalers: runtime.NewRegistry(opts...),
 	}
 }
 
+// WithMarshaler registers m as the Marshaler of mediaType for the request and response bodies,
+// replacing the one registered before, and returns the KnownTypesServiceHTTPConverter.
+func (h *KnownTypesServiceHTTPConverter) WithMarshaler(mediaType string, m runtime.Marshaler) *KnownTypesServiceHTTPConverter {
+	h.marshalers.Add(mediaType, m)
+	return h
+}
+
 // Any returns KnownTypesServiceHTTPService interface's Any converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) Any(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &anypb.Any{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/Any",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.Any(c, req.(*anypb.Any))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -159,34 +99,11 @@ func (h *KnownTypesServiceHTTPConverter) Any(cb func(ctx context.Context, w http
 
 		ret, ok := iret.(*anypb.Any)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/Any: interceptors have not return anypb.Any"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/Any: interceptors have not return anypb.Any"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -202,101 +119,36 @@ func (h *KnownTypesServiceHTTPConverter) AnyWithName(cb func(ctx context.Context
 // Api returns KnownTypesServiceHTTPService interface's Api converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) Api(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &apipb.Api{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/Api",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.Api(c, req.(*apipb.Api))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -304,34 +156,11 @@ func (h *KnownTypesServiceHTTPConverter) Api(cb func(ctx context.Context, w http
 
 		ret, ok := iret.(*apipb.Api)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/Api: interceptors have not return apipb.Api"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/Api: interceptors have not return apipb.Api"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -347,101 +176,36 @@ func (h *KnownTypesServiceHTTPConverter) ApiWithName(cb func(ctx context.Context
 // Duration returns KnownTypesServiceHTTPService interface's Duration converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) Duration(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &durationpb.Duration{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/Duration",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.Duration(c, req.(*durationpb.Duration))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -449,34 +213,11 @@ func (h *KnownTypesServiceHTTPConverter) Duration(cb func(ctx context.Context, w
 
 		ret, ok := iret.(*durationpb.Duration)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/Duration: interceptors have not return durationpb.Duration"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/Duration: interceptors have not return durationpb.Duration"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -492,101 +233,36 @@ func (h *KnownTypesServiceHTTPConverter) DurationWithName(cb func(ctx context.Co
 // Empty returns KnownTypesServiceHTTPService interface's Empty converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) Empty(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &emptypb.Empty{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/Empty",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.Empty(c, req.(*emptypb.Empty))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -594,34 +270,11 @@ func (h *KnownTypesServiceHTTPConverter) Empty(cb func(ctx context.Context, w ht
 
 		ret, ok := iret.(*emptypb.Empty)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/Empty: interceptors have not return emptypb.Empty"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/Empty: interceptors have not return emptypb.Empty"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -637,101 +290,36 @@ func (h *KnownTypesServiceHTTPConverter) EmptyWithName(cb func(ctx context.Conte
 // FieldMask returns KnownTypesServiceHTTPService interface's FieldMask converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) FieldMask(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &fieldmaskpb.FieldMask{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/FieldMask",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.FieldMask(c, req.(*fieldmaskpb.FieldMask))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -739,34 +327,11 @@ func (h *KnownTypesServiceHTTPConverter) FieldMask(cb func(ctx context.Context,
 
 		ret, ok := iret.(*fieldmaskpb.FieldMask)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/FieldMask: interceptors have not return fieldmaskpb.FieldMask"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/FieldMask: interceptors have not return fieldmaskpb.FieldMask"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -782,101 +347,36 @@ func (h *KnownTypesServiceHTTPConverter) FieldMaskWithName(cb func(ctx context.C
 // SourceContext returns KnownTypesServiceHTTPService interface's SourceContext converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) SourceContext(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &sourcecontextpb.SourceContext{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-		}
-
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
 		}
 
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/SourceContext",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.SourceContext(c, req.(*sourcecontextpb.SourceContext))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -884,34 +384,11 @@ func (h *KnownTypesServiceHTTPConverter) SourceContext(cb func(ctx context.Conte
 
 		ret, ok := iret.(*sourcecontextpb.SourceContext)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/SourceContext: interceptors have not return sourcecontextpb.SourceContext"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/SourceContext: interceptors have not return sourcecontextpb.SourceContext"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -927,136 +404,48 @@ func (h *KnownTypesServiceHTTPConverter) SourceContextWithName(cb func(ctx conte
 // Struct returns KnownTypesServiceHTTPService interface's Struct converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) Struct(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
-		arg := &status.Struct{}
+		arg := &structpb.Struct{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/Struct",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
-			return h.srv.Struct(c, req.(*status.Struct))
+			return h.srv.Struct(c, req.(*structpb.Struct))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
 		}
 
-		ret, ok := iret.(*status.Struct)
+		ret, ok := iret.(*structpb.Struct)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/Struct: interceptors have not return status.Struct"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/Struct: interceptors have not return structpb.Struct"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -1072,101 +461,36 @@ func (h *KnownTypesServiceHTTPConverter) StructWithName(cb func(ctx context.Cont
 // Timestamp returns KnownTypesServiceHTTPService interface's Timestamp converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) Timestamp(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
-
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &timestamppb.Timestamp{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/Timestamp",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.Timestamp(c, req.(*timestamppb.Timestamp))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -1174,34 +498,11 @@ func (h *KnownTypesServiceHTTPConverter) Timestamp(cb func(ctx context.Context,
 
 		ret, ok := iret.(*timestamppb.Timestamp)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/Timestamp: interceptors have not return timestamppb.Timestamp"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/Timestamp: interceptors have not return timestamppb.Timestamp"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -1217,101 +518,36 @@ func (h *KnownTypesServiceHTTPConverter) TimestampWithName(cb func(ctx context.C
 // Type returns KnownTypesServiceHTTPService interface's Type converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) Type(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &typepb.Type{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/Type",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.Type(c, req.(*typepb.Type))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -1319,34 +555,11 @@ func (h *KnownTypesServiceHTTPConverter) Type(cb func(ctx context.Context, w htt
 
 		ret, ok := iret.(*typepb.Type)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/Type: interceptors have not return typepb.Type"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/Type: interceptors have not return typepb.Type"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}
@@ -1362,101 +575,36 @@ func (h *KnownTypesServiceHTTPConverter) TypeWithName(cb func(ctx context.Contex
 // Wrappers returns KnownTypesServiceHTTPService interface's Wrappers converted to http.HandlerFunc.
 func (h *KnownTypesServiceHTTPConverter) Wrappers(cb func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error), interceptors ...grpc.UnaryServerInterceptor) http.HandlerFunc {
 	if cb == nil {
-		cb = func(ctx context.Context, w http.ResponseWriter, r *http.Request, arg, ret proto.Message, err error) {
-			if err != nil {
-				w.WriteHeader(http.StatusInternalServerError)
-				p := status.New(codes.Unknown, err.Error()).Proto()
-				switch contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); contentType {
-				case "application/protobuf", "application/x-protobuf":
-					buf, err := proto.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				case "application/json":
-					buf, err := protojson.Marshal(p)
-					if err != nil {
-						return
-					}
-					if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-						return
-					}
-				default:
-				}
-			}
-		}
+		cb = runtime.DefaultCallback
 	}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		w = runtime.NewResponseWriter(w)
+		ctx := runtime.NewContext(r.Context(), h.marshalers)
 
-		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
-
-		accepts := strings.Split(r.Header.Get("Accept"), ",")
-		accept := accepts[0]
-		if accept == "*/*" || accept == "" {
-			if contentType != "" {
-				accept = contentType
-			} else {
-				accept = "application/json"
-			}
+		contentType, accept, err := h.marshalers.ContentTypes(r)
+		if err != nil {
+			cb(ctx, w, r, nil, nil, err)
+			return
 		}
 
-		w.Header().Set("Content-Type", accept)
-
 		arg := &wrapperspb.BoolValue{}
 		if r.Method != http.MethodGet {
-			body, err := ioutil.ReadAll(r.Body)
-			if err != nil {
-				cb(ctx, w, r, nil, nil, err)
-				return
-			}
-
-			switch contentType {
-			case "application/protobuf", "application/x-protobuf":
-				if err := proto.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			case "application/json":
-				if err := protojson.Unmarshal(body, arg); err != nil {
-					cb(ctx, w, r, nil, nil, err)
-					return
-				}
-			default:
-				w.WriteHeader(http.StatusUnsupportedMediaType)
-				_, err := fmt.Fprintf(w, "Unsupported Content-Type: %s", contentType)
+			if err := h.marshalers.UnmarshalRequest(r, contentType, arg, ""); err != nil {
 				cb(ctx, w, r, nil, nil, err)
 				return
 			}
 		}
 
-		n := len(interceptors)
-		chained := func(ctx context.Context, arg interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-			chainer := func(currentInter grpc.UnaryServerInterceptor, currentHandler grpc.UnaryHandler) grpc.UnaryHandler {
-				return func(currentCtx context.Context, currentReq interface{}) (interface{}, error) {
-					return currentInter(currentCtx, currentReq, info, currentHandler)
-				}
-			}
-
-			chainedHandler := handler
-			for i := n - 1; i >= 0; i-- {
-				chainedHandler = chainer(interceptors[i], chainedHandler)
-			}
-			return chainedHandler(ctx, arg)
-		}
-
 		info := &grpc.UnaryServerInfo{
 			Server:     h.srv,
 			FullMethod: "/knowntypes.KnownTypesService/Wrappers",
 		}
-
 		handler := func(c context.Context, req interface{}) (interface{}, error) {
 			return h.srv.Wrappers(c, req.(*wrapperspb.BoolValue))
 		}
-
-		iret, err := chained(ctx, arg, info, handler)
+		ctx, stream := runtime.NewServerTransportStream(ctx, info.FullMethod)
+		iret, err := runtime.ChainUnaryServer(interceptors...)(ctx, arg, info, handler)
+		h.marshalers.WriteMetadata(w, stream)
 		if err != nil {
 			cb(ctx, w, r, arg, nil, err)
 			return
@@ -1464,34 +612,11 @@ func (h *KnownTypesServiceHTTPConverter) Wrappers(cb func(ctx context.Context, w
 
 		ret, ok := iret.(*wrapperspb.BoolValue)
 		if !ok {
-			cb(ctx, w, r, arg, nil, fmt.Errorf("/knowntypes.KnownTypesService/Wrappers: interceptors have not return wrapperspb.BoolValue"))
+			cb(ctx, w, r, arg, nil, status.Errorf(codes.Internal, "/knowntypes.KnownTypesService/Wrappers: interceptors have not return wrapperspb.BoolValue"))
 			return
 		}
 
-		switch accept {
-		case "application/protobuf", "application/x-protobuf":
-			buf, err := proto.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		case "application/json":
-			buf, err := protojson.Marshal(ret)
-			if err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-			if _, err := io.Copy(w, bytes.NewBuffer(buf)); err != nil {
-				cb(ctx, w, r, arg, ret, err)
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusUnsupportedMediaType)
-			_, err := fmt.Fprintf(w, "Unsupported Accept: %s", accept)
+		if err := h.marshalers.WriteResponse(w, accept, ret, ""); err != nil {
 			cb(ctx, w, r, arg, ret, err)
 			return
 		}